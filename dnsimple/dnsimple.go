@@ -67,6 +67,11 @@ type Client struct {
 
 	// Set to true to output debugging logs during API calls
 	Debug bool
+
+	// Set to true to skip the validation of the regions in Zones.CreateRecord, Zones.UpdateRecord
+	// and Zones.BatchChangeRecords, to send regions missing from the ZoneRecordRegions catalog
+	// to the API as they are.
+	SkipRegionValidation bool
 }
 
 // ListOptions contains the common options you can pass to a List method
@@ -313,6 +318,10 @@ type ErrorResponse struct {
 
 	// detailed validation errors
 	AttributeErrors map[string][]string `json:"errors"`
+
+	// the errors node as returned by the API,
+	// for endpoints that return structured errors
+	rawErrors json.RawMessage
 }
 
 // Error implements the error interface.
@@ -322,6 +331,12 @@ func (r *ErrorResponse) Error() string {
 		r.HTTPResponse.StatusCode, r.Message)
 }
 
+// isNotFound reports whether err is an API error response with the 404 Not Found status.
+func isNotFound(err error) bool {
+	var errorResponse *ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.HTTPResponse != nil && errorResponse.HTTPResponse.StatusCode == http.StatusNotFound
+}
+
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if the status code is different than 2xx. Specific requests
 // may have additional requirements, but this is sufficient in most of the cases.
@@ -333,11 +348,26 @@ func CheckResponse(resp *http.Response) error {
 	errorResponse := &ErrorResponse{}
 	errorResponse.HTTPResponse = resp
 
-	err := json.NewDecoder(resp.Body).Decode(errorResponse)
+	body := &struct {
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}{}
+	err := json.NewDecoder(resp.Body).Decode(body)
 	if err != nil {
 		return err
 	}
 
+	errorResponse.Message = body.Message
+	errorResponse.rawErrors = body.Errors
+
+	// Validation errors are generally a map of attribute names to messages,
+	// but some endpoints return structured errors, such as ZonesService.BatchChangeRecords,
+	// that are decoded into an endpoint specific error type.
+	var attributeErrors map[string][]string
+	if len(body.Errors) > 0 && json.Unmarshal(body.Errors, &attributeErrors) == nil {
+		errorResponse.AttributeErrors = attributeErrors
+	}
+
 	return errorResponse
 }
 
@@ -0,0 +1,361 @@
+package dnssec
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// The DS digest types, see https://www.iana.org/assignments/ds-rr-types/
+const (
+	DigestTypeSHA1   uint8 = 1
+	DigestTypeSHA256 uint8 = 2
+	DigestTypeSHA384 uint8 = 4
+)
+
+// The flags of a DNSKEY, see RFC 4034, section 2.1.1.
+const (
+	FlagZoneKey          uint16 = 256
+	FlagSecureEntryPoint uint16 = 1
+	FlagsKeySigningKey          = FlagZoneKey | FlagSecureEntryPoint
+	dnskeyProtocol       uint8  = 3
+	algorithmRSAMD5      uint8  = 1
+)
+
+// The DNSSEC algorithms that can be used in DS and DNSKEY records,
+// see https://www.iana.org/assignments/dns-sec-alg-numbers/
+var algorithms = map[uint8]string{
+	1:  "RSAMD5",
+	3:  "DSA",
+	5:  "RSASHA1",
+	6:  "DSA-NSEC3-SHA1",
+	7:  "RSASHA1-NSEC3-SHA1",
+	8:  "RSASHA256",
+	10: "RSASHA512",
+	12: "ECC-GOST",
+	13: "ECDSAP256SHA256",
+	14: "ECDSAP384SHA384",
+	15: "ED25519",
+	16: "ED448",
+}
+
+// The length of the digest, in bytes, of each digest type.
+var digestLengths = map[uint8]int{
+	DigestTypeSHA1:   sha1.Size,
+	DigestTypeSHA256: sha256.Size,
+	DigestTypeSHA384: sha512.Size384,
+}
+
+// DNSKEY represents the data of a DNSKEY record, see RFC 4034, section 2.
+type DNSKEY struct {
+	Flags     uint16
+	Protocol  uint8
+	Algorithm uint8
+	// The public key, base64 encoded as in the presentation format.
+	PublicKey string
+}
+
+// ParseDNSKEY parses the presentation format of the data of a DNSKEY record,
+// such as "257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ==".
+// The public key may be split in several fields.
+func ParseDNSKEY(s string) (DNSKEY, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 4 {
+		return DNSKEY{}, fmt.Errorf("invalid DNSKEY %q: expected flags, protocol, algorithm and public key", s)
+	}
+
+	flags, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("invalid DNSKEY flags %q", fields[0])
+	}
+	protocol, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("invalid DNSKEY protocol %q", fields[1])
+	}
+	algorithm, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return DNSKEY{}, fmt.Errorf("invalid DNSKEY algorithm %q", fields[2])
+	}
+
+	key := DNSKEY{Flags: uint16(flags), Protocol: uint8(protocol), Algorithm: uint8(algorithm), PublicKey: strings.Join(fields[3:], "")}
+	if _, err := key.Wire(); err != nil {
+		return DNSKEY{}, err
+	}
+	return key, nil
+}
+
+// String returns the presentation format of the DNSKEY data.
+func (k DNSKEY) String() string {
+	return fmt.Sprintf("%d %d %d %v", k.Flags, k.Protocol, k.Algorithm, k.PublicKey)
+}
+
+// Wire returns the wire format of the DNSKEY data, see RFC 4034, section 2.1.
+func (k DNSKEY) Wire() ([]byte, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DNSKEY public key: %w", err)
+	}
+	if len(publicKey) == 0 {
+		return nil, errors.New("invalid DNSKEY public key: empty")
+	}
+
+	wire := make([]byte, 4, 4+len(publicKey))
+	binary.BigEndian.PutUint16(wire, k.Flags)
+	wire[2] = k.Protocol
+	wire[3] = k.Algorithm
+	return append(wire, publicKey...), nil
+}
+
+// DecodeDNSKEY decodes the wire format of the DNSKEY data.
+func DecodeDNSKEY(wire []byte) (DNSKEY, error) {
+	if len(wire) < 5 {
+		return DNSKEY{}, errors.New("invalid DNSKEY wire data: too short")
+	}
+	return DNSKEY{
+		Flags:     binary.BigEndian.Uint16(wire),
+		Protocol:  wire[2],
+		Algorithm: wire[3],
+		PublicKey: base64.StdEncoding.EncodeToString(wire[4:]),
+	}, nil
+}
+
+// KeyTag computes the key tag of the DNSKEY, see RFC 4034, appendix B.
+func (k DNSKEY) KeyTag() (uint16, error) {
+	wire, err := k.Wire()
+	if err != nil {
+		return 0, err
+	}
+
+	if k.Algorithm == algorithmRSAMD5 {
+		// the key tag is the most significant 16 bits of the least significant 24 bits of the modulus
+		if len(wire) < 4+3 {
+			return 0, errors.New("invalid DNSKEY public key: too short")
+		}
+		return binary.BigEndian.Uint16(wire[len(wire)-3:]), nil
+	}
+
+	var ac uint32
+	for i, b := range wire {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return uint16(ac & 0xFFFF), nil
+}
+
+// Digest computes the digest of the DNSKEY of the owner domain, as upper case hex,
+// see RFC 4034, section 5.1.4 and RFC 4509, section 2.1.
+func (k DNSKEY) Digest(owner string, digestType uint8) (string, error) {
+	var h hash.Hash
+	switch digestType {
+	case DigestTypeSHA1:
+		h = sha1.New()
+	case DigestTypeSHA256:
+		h = sha256.New()
+	case DigestTypeSHA384:
+		h = sha512.New384()
+	default:
+		return "", fmt.Errorf("unsupported digest type %d", digestType)
+	}
+
+	name, err := canonicalWireName(owner)
+	if err != nil {
+		return "", err
+	}
+	wire, err := k.Wire()
+	if err != nil {
+		return "", err
+	}
+
+	h.Write(name)
+	h.Write(wire)
+	return strings.ToUpper(hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// DS computes the DS record of the DNSKEY of the owner domain, with the given digest type.
+func (k DNSKEY) DS(owner string, digestType uint8) (dnsimple.DelegationSignerRecord, error) {
+	keyTag, err := k.KeyTag()
+	if err != nil {
+		return dnsimple.DelegationSignerRecord{}, err
+	}
+	digest, err := k.Digest(owner, digestType)
+	if err != nil {
+		return dnsimple.DelegationSignerRecord{}, err
+	}
+
+	return dnsimple.DelegationSignerRecord{
+		Algorithm:  strconv.Itoa(int(k.Algorithm)),
+		Digest:     digest,
+		DigestType: strconv.Itoa(int(digestType)),
+		Keytag:     strconv.Itoa(int(keyTag)),
+	}, nil
+}
+
+// DelegationSignerRecordForTld returns the record to submit with CreateDelegationSignerRecord
+// for a domain in the TLD: TLDs with the "key" DNSSEC interface type require the algorithm
+// and the public key, the other TLDs the key tag and the digest.
+func DelegationSignerRecordForTld(tld dnsimple.Tld, owner string, key DNSKEY, digestType uint8) (dnsimple.DelegationSignerRecord, error) {
+	if tld.DnssecInterfaceType == "key" {
+		if _, err := key.Wire(); err != nil {
+			return dnsimple.DelegationSignerRecord{}, err
+		}
+		return dnsimple.DelegationSignerRecord{Algorithm: strconv.Itoa(int(key.Algorithm)), PublicKey: key.PublicKey}, nil
+	}
+
+	return key.DS(owner, digestType)
+}
+
+// DNSKEYFromRecord returns the DNSKEY of a DelegationSignerRecord that carries a public key.
+// The key is assumed to be a key signing key, the only keys published in the parent zone.
+func DNSKEYFromRecord(ds dnsimple.DelegationSignerRecord) (DNSKEY, error) {
+	if ds.PublicKey == "" {
+		return DNSKEY{}, errors.New("delegation signer record has no public key")
+	}
+	algorithm, err := parseAlgorithm(ds.Algorithm)
+	if err != nil {
+		return DNSKEY{}, err
+	}
+
+	key := DNSKEY{Flags: FlagsKeySigningKey, Protocol: dnskeyProtocol, Algorithm: algorithm, PublicKey: ds.PublicKey}
+	if _, err := key.Wire(); err != nil {
+		return DNSKEY{}, err
+	}
+	return key, nil
+}
+
+// EncodeDS returns the wire format of the data of the DS record, see RFC 4034, section 5.1.
+func EncodeDS(ds dnsimple.DelegationSignerRecord) ([]byte, error) {
+	keyTag, err := strconv.ParseUint(ds.Keytag, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key tag %q", ds.Keytag)
+	}
+	algorithm, err := parseAlgorithm(ds.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	digestType, err := parseDigestType(ds.DigestType)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := hex.DecodeString(ds.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest: %w", err)
+	}
+
+	wire := make([]byte, 4, 4+len(digest))
+	binary.BigEndian.PutUint16(wire, uint16(keyTag))
+	wire[2] = algorithm
+	wire[3] = digestType
+	return append(wire, digest...), nil
+}
+
+// DecodeDS decodes the wire format of the data of a DS record.
+func DecodeDS(wire []byte) (dnsimple.DelegationSignerRecord, error) {
+	if len(wire) < 5 {
+		return dnsimple.DelegationSignerRecord{}, errors.New("invalid DS wire data: too short")
+	}
+	return dnsimple.DelegationSignerRecord{
+		Keytag:     strconv.Itoa(int(binary.BigEndian.Uint16(wire))),
+		Algorithm:  strconv.Itoa(int(wire[2])),
+		DigestType: strconv.Itoa(int(wire[3])),
+		Digest:     strings.ToUpper(hex.EncodeToString(wire[4:])),
+	}, nil
+}
+
+// ValidateDelegationSignerRecord checks a DelegationSignerRecord of the owner domain
+// before it is submitted with CreateDelegationSignerRecord.
+//
+// The algorithm, digest type, key tag and digest must be well formed. When the record carries
+// a public key, the key tag and the digest, if present, must match the ones computed from the key.
+func ValidateDelegationSignerRecord(owner string, ds dnsimple.DelegationSignerRecord) error {
+	if _, err := parseAlgorithm(ds.Algorithm); err != nil {
+		return err
+	}
+
+	if ds.Digest != "" || ds.PublicKey == "" {
+		if _, err := EncodeDS(ds); err != nil {
+			return err
+		}
+		digestType, _ := parseDigestType(ds.DigestType)
+		if length := len(ds.Digest) / 2; length != digestLengths[digestType] {
+			return fmt.Errorf("invalid digest: %d bytes, digest type %d requires %d", length, digestType, digestLengths[digestType])
+		}
+	}
+
+	if ds.PublicKey == "" {
+		return nil
+	}
+
+	key, err := DNSKEYFromRecord(ds)
+	if err != nil {
+		return err
+	}
+	keyTag, err := key.KeyTag()
+	if err != nil {
+		return err
+	}
+	if ds.Keytag != "" && ds.Keytag != strconv.Itoa(int(keyTag)) {
+		return fmt.Errorf("key tag %v does not match the public key, expected %d", ds.Keytag, keyTag)
+	}
+	if ds.Digest != "" {
+		digestType, _ := parseDigestType(ds.DigestType)
+		digest, err := key.Digest(owner, digestType)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(digest, ds.Digest) {
+			return fmt.Errorf("digest does not match the public key of %v", owner)
+		}
+	}
+	return nil
+}
+
+func parseAlgorithm(s string) (uint8, error) {
+	algorithm, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || algorithms[uint8(algorithm)] == "" {
+		return 0, fmt.Errorf("invalid algorithm %q", s)
+	}
+	return uint8(algorithm), nil
+}
+
+func parseDigestType(s string) (uint8, error) {
+	digestType, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || digestLengths[uint8(digestType)] == 0 {
+		return 0, fmt.Errorf("invalid digest type %q", s)
+	}
+	return uint8(digestType), nil
+}
+
+// canonicalWireName returns the canonical wire format of a domain name, see RFC 4034, section 6.2.
+func canonicalWireName(name string) ([]byte, error) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	var wire []byte
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) == 0 || len(label) > 63 {
+				return nil, fmt.Errorf("invalid domain name %q", name)
+			}
+			wire = append(wire, byte(len(label)))
+			wire = append(wire, label...)
+		}
+	}
+	wire = append(wire, 0)
+	if len(wire) > 255 {
+		return nil, fmt.Errorf("invalid domain name %q: too long", name)
+	}
+	return wire, nil
+}
@@ -0,0 +1,128 @@
+package dnssec
+
+import (
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/stretchr/testify/assert"
+)
+
+// The key of the examples of RFC 4034, section 5.4 and RFC 4509, section 2.3.
+const exampleDNSKEY = "256 3 5 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/ 2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+
+func TestDNSKEY_KeyTagAndDigest(t *testing.T) {
+	key, err := ParseDNSKEY(exampleDNSKEY)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(256), key.Flags)
+	assert.Equal(t, uint8(5), key.Algorithm)
+
+	keyTag, err := key.KeyTag()
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(60485), keyTag)
+
+	digest, err := key.Digest("dskey.example.com.", DigestTypeSHA1)
+	assert.NoError(t, err)
+	assert.Equal(t, "2BB183AF5F22588179A53B0A98631FAD1A292118", digest)
+
+	digest, err = key.Digest("DSKEY.example.com", DigestTypeSHA256)
+	assert.NoError(t, err)
+	assert.Equal(t, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", digest)
+
+	digest, err = key.Digest("dskey.example.com", DigestTypeSHA384)
+	assert.NoError(t, err)
+	assert.Len(t, digest, 96)
+
+	_, err = key.Digest("dskey.example.com", 3)
+	assert.EqualError(t, err, "unsupported digest type 3")
+}
+
+func TestDNSKEY_DS(t *testing.T) {
+	key, _ := ParseDNSKEY(exampleDNSKEY)
+
+	ds, err := key.DS("dskey.example.com", DigestTypeSHA256)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "60485", ds.Keytag)
+	assert.Equal(t, "5", ds.Algorithm)
+	assert.Equal(t, "2", ds.DigestType)
+	assert.Equal(t, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", ds.Digest)
+	assert.NoError(t, ValidateDelegationSignerRecord("dskey.example.com", ds))
+}
+
+func TestDNSKEY_Wire(t *testing.T) {
+	key, _ := ParseDNSKEY(exampleDNSKEY)
+
+	wire, err := key.Wire()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x00, 0x03, 0x05}, wire[:4])
+
+	decoded, err := DecodeDNSKEY(wire)
+	assert.NoError(t, err)
+	assert.Equal(t, key, decoded)
+
+	_, err = ParseDNSKEY("257 3 13")
+	assert.Error(t, err)
+	_, err = ParseDNSKEY("257 3 13 not-base64!")
+	assert.Error(t, err)
+}
+
+func TestEncodeDS(t *testing.T) {
+	ds := dnsimple.DelegationSignerRecord{Keytag: "60485", Algorithm: "5", DigestType: "1", Digest: "2BB183AF5F22588179A53B0A98631FAD1A292118"}
+
+	wire, err := EncodeDS(ds)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0xEC, 0x45, 0x05, 0x01, 0x2B, 0xB1}, wire[:6])
+
+	decoded, err := DecodeDS(wire)
+	assert.NoError(t, err)
+	assert.Equal(t, ds, decoded)
+}
+
+func TestValidateDelegationSignerRecord(t *testing.T) {
+	valid := dnsimple.DelegationSignerRecord{Keytag: "60485", Algorithm: "5", DigestType: "1", Digest: "2BB183AF5F22588179A53B0A98631FAD1A292118"}
+	assert.NoError(t, ValidateDelegationSignerRecord("dskey.example.com", valid))
+
+	invalid := valid
+	invalid.Algorithm = "4"
+	assert.EqualError(t, ValidateDelegationSignerRecord("dskey.example.com", invalid), `invalid algorithm "4"`)
+
+	invalid = valid
+	invalid.DigestType = "2"
+	assert.EqualError(t, ValidateDelegationSignerRecord("dskey.example.com", invalid), "invalid digest: 20 bytes, digest type 2 requires 32")
+
+	invalid = valid
+	invalid.Keytag = "70000"
+	assert.EqualError(t, ValidateDelegationSignerRecord("dskey.example.com", invalid), `invalid key tag "70000"`)
+
+	key, _ := ParseDNSKEY(exampleDNSKEY)
+	withKey := valid
+	withKey.PublicKey = key.PublicKey
+	// the public key of a DelegationSignerRecord is assumed to be a key signing key
+	assert.EqualError(t, ValidateDelegationSignerRecord("dskey.example.com", withKey), "key tag 60485 does not match the public key, expected 60486")
+
+	ksk := key
+	ksk.Flags = FlagsKeySigningKey
+	ds, err := ksk.DS("dskey.example.com", DigestTypeSHA256)
+	assert.NoError(t, err)
+	ds.PublicKey = ksk.PublicKey
+	assert.NoError(t, ValidateDelegationSignerRecord("dskey.example.com", ds))
+	assert.EqualError(t, ValidateDelegationSignerRecord("other.example.com", ds), "digest does not match the public key of other.example.com")
+}
+
+func TestDelegationSignerRecordForTld(t *testing.T) {
+	key, _ := ParseDNSKEY(exampleDNSKEY)
+	key.Flags = FlagsKeySigningKey
+
+	ds, err := DelegationSignerRecordForTld(dnsimple.Tld{Tld: "com", DnssecInterfaceType: "ds"}, "dskey.example.com", key, DigestTypeSHA256)
+	assert.NoError(t, err)
+	assert.Empty(t, ds.PublicKey)
+	assert.NotEmpty(t, ds.Digest)
+
+	ds, err = DelegationSignerRecordForTld(dnsimple.Tld{Tld: "eu", DnssecInterfaceType: "key"}, "dskey.example.com", key, DigestTypeSHA256)
+	assert.NoError(t, err)
+	assert.Equal(t, dnsimple.DelegationSignerRecord{Algorithm: "5", PublicKey: key.PublicKey}, ds)
+
+	converted, err := DNSKEYFromRecord(ds)
+	assert.NoError(t, err)
+	assert.Equal(t, key, converted)
+}
@@ -0,0 +1,284 @@
+// Package dnssec provides tools to manage the DNSSEC setup of DNSimple domains,
+// such as key rollovers for domains registered with other registrars.
+package dnssec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dnsimple/dnsimple-go/dnsimple/webhook"
+)
+
+// ParentRegistrar publishes the DS records of a domain in its parent zone.
+//
+// Implementations talk to the registrar of the domain. AddDS and RemoveDS must be idempotent:
+// adding a DS record already published, or removing one that isn't, must succeed.
+type ParentRegistrar interface {
+	// ListDS returns the DS records of the domain currently published in the parent zone.
+	ListDS(ctx context.Context, domainName string) ([]dnsimple.DelegationSignerRecord, error)
+	AddDS(ctx context.Context, domainName string, ds dnsimple.DelegationSignerRecord) error
+	RemoveDS(ctx context.Context, domainName string, ds dnsimple.DelegationSignerRecord) error
+}
+
+// RolloverPhase represents the phase of a key rollover.
+type RolloverPhase string
+
+const (
+	// RolloverStarted is the phase after a dnssec.rotation_start event:
+	// the new DS record is published along with the old ones.
+	RolloverStarted RolloverPhase = "started"
+
+	// RolloverCompleted is the phase after a dnssec.rotation_complete event:
+	// the old DS records are removed.
+	RolloverCompleted RolloverPhase = "completed"
+)
+
+// RolloverState represents the progress of the key rollover of a domain.
+type RolloverState struct {
+	AccountID  string        `json:"account_id"`
+	DomainID   int64         `json:"domain_id"`
+	DomainName string        `json:"domain_name"`
+	Phase      RolloverPhase `json:"phase"`
+
+	// The DS record of the new key, and whether it has been published at the parent.
+	NewDS          dnsimple.DelegationSignerRecord `json:"new_ds"`
+	NewDSPublished bool                            `json:"new_ds_published"`
+
+	// The DS records to remove from the parent once the rotation completes.
+	// Records are removed from the list as soon as they are removed at the parent.
+	OldDS []dnsimple.DelegationSignerRecord `json:"old_ds"`
+
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Done reports whether the rollover has nothing left to do.
+func (s *RolloverState) Done() bool {
+	return s.Phase == RolloverCompleted && s.NewDSPublished && len(s.OldDS) == 0
+}
+
+// RolloverCoordinator drives the key rollovers of domains registered with other registrars,
+// reacting to the dnssec.rotation_start and dnssec.rotation_complete webhook events.
+//
+// On rotation start the new DS record is published at the parent through the ParentRegistrar,
+// and the DS records the parent published before the rotation are recorded. The old DS records are removed
+// from the parent only when the rotation completes.
+//
+// The state of each rollover is saved to the RolloverStore after every change,
+// so that a coordinator can be restarted and pick up pending rollovers with Resume.
+type RolloverCoordinator struct {
+	client    *dnsimple.Client
+	registrar ParentRegistrar
+	store     RolloverStore
+
+	// now can be replaced in tests
+	now func() time.Time
+}
+
+// NewRolloverCoordinator returns a coordinator that publishes the DS records through the registrar,
+// and saves the state of the rollovers to the store.
+func NewRolloverCoordinator(client *dnsimple.Client, registrar ParentRegistrar, store RolloverStore) *RolloverCoordinator {
+	return &RolloverCoordinator{client: client, registrar: registrar, store: store, now: time.Now}
+}
+
+// HandleEvent processes a webhook event. Events other than the DNSSEC rotation events are ignored.
+//
+// An error is returned when the rollover could not progress, in which case the webhook
+// should be retried, or the rollover resumed later with Resume.
+func (c *RolloverCoordinator) HandleEvent(ctx context.Context, event *webhook.Event) error {
+	if event.Name != "dnssec.rotation_start" && event.Name != "dnssec.rotation_complete" {
+		return nil
+	}
+
+	data, ok := event.GetData().(*webhook.DNSSECEventData)
+	if !ok || data.DelegationSignerRecord == nil {
+		return fmt.Errorf("%v event without delegation signer record", event.Name)
+	}
+	if event.Account == nil {
+		return fmt.Errorf("%v event without account", event.Name)
+	}
+
+	ds := *data.DelegationSignerRecord
+	accountID := strconv.FormatInt(event.Account.ID, 10)
+
+	if event.Name == "dnssec.rotation_start" {
+		return c.start(ctx, accountID, ds)
+	}
+	return c.complete(ctx, accountID, ds)
+}
+
+// Resume carries on the rollovers of the store that are not done,
+// for example after a restart or a failure of the parent registrar.
+func (c *RolloverCoordinator) Resume(ctx context.Context) error {
+	states, err := c.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, state := range states {
+		if state.Done() {
+			continue
+		}
+		if err := c.advance(ctx, state); err != nil {
+			errs = append(errs, fmt.Sprintf("%v: %v", state.DomainName, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New("resume rollovers: " + strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (c *RolloverCoordinator) start(ctx context.Context, accountID string, ds dnsimple.DelegationSignerRecord) error {
+	state, err := c.store.Load(ctx, ds.DomainID)
+	if err != nil {
+		return err
+	}
+	if state != nil && state.Phase == RolloverStarted && sameDS(state.NewDS, ds) {
+		// the event was delivered again
+		return c.advance(ctx, state)
+	}
+
+	domainResponse, err := c.client.Domains.GetDomain(ctx, accountID, strconv.FormatInt(ds.DomainID, 10))
+	if err != nil {
+		return err
+	}
+	// the DS records to remove are the ones the parent publishes,
+	// which may differ from the ones recorded in DNSimple
+	published, err := c.registrar.ListDS(ctx, domainResponse.Data.Name)
+	if err != nil {
+		return fmt.Errorf("list DS: %w", err)
+	}
+
+	next := &RolloverState{
+		AccountID:  accountID,
+		DomainID:   ds.DomainID,
+		DomainName: domainResponse.Data.Name,
+		Phase:      RolloverStarted,
+		NewDS:      ds,
+		StartedAt:  c.now(),
+	}
+	for _, current := range published {
+		if !sameDS(current, ds) {
+			next.OldDS = appendDS(next.OldDS, current)
+		}
+	}
+	if state != nil {
+		// a new rotation started before the previous one completed:
+		// the DS records of the previous rotation are old as well
+		for _, previous := range state.OldDS {
+			next.OldDS = appendDS(next.OldDS, previous)
+		}
+		if !sameDS(state.NewDS, ds) {
+			next.OldDS = appendDS(next.OldDS, state.NewDS)
+		}
+	}
+
+	if err := c.store.Save(ctx, next); err != nil {
+		return err
+	}
+	return c.advance(ctx, next)
+}
+
+func (c *RolloverCoordinator) complete(ctx context.Context, accountID string, ds dnsimple.DelegationSignerRecord) error {
+	state, err := c.store.Load(ctx, ds.DomainID)
+	if err != nil {
+		return err
+	}
+
+	if state == nil {
+		// the start of the rotation was missed: every DS the parent publishes,
+		// other than the new one, is old
+		domainResponse, err := c.client.Domains.GetDomain(ctx, accountID, strconv.FormatInt(ds.DomainID, 10))
+		if err != nil {
+			return err
+		}
+		published, err := c.registrar.ListDS(ctx, domainResponse.Data.Name)
+		if err != nil {
+			return fmt.Errorf("list DS: %w", err)
+		}
+		state = &RolloverState{AccountID: accountID, DomainID: ds.DomainID, DomainName: domainResponse.Data.Name, NewDS: ds}
+		for _, current := range published {
+			if !sameDS(current, ds) {
+				state.OldDS = appendDS(state.OldDS, current)
+			}
+		}
+	}
+
+	if !sameDS(state.NewDS, ds) {
+		// the rotation completed with a different key than the one announced at the start
+		state.OldDS = appendDS(state.OldDS, state.NewDS)
+		state.NewDS = ds
+		state.NewDSPublished = false
+	}
+	state.OldDS = removeDS(state.OldDS, ds)
+	if state.Phase != RolloverCompleted {
+		state.Phase = RolloverCompleted
+		state.CompletedAt = c.now()
+	}
+
+	if err := c.store.Save(ctx, state); err != nil {
+		return err
+	}
+	return c.advance(ctx, state)
+}
+
+// advance performs the pending actions of the rollover, saving the state after each of them.
+func (c *RolloverCoordinator) advance(ctx context.Context, state *RolloverState) error {
+	if !state.NewDSPublished {
+		if err := c.registrar.AddDS(ctx, state.DomainName, state.NewDS); err != nil {
+			return fmt.Errorf("add DS %v: %w", state.NewDS.Keytag, err)
+		}
+		state.NewDSPublished = true
+		if err := c.store.Save(ctx, state); err != nil {
+			return err
+		}
+	}
+
+	if state.Phase != RolloverCompleted {
+		return nil
+	}
+
+	for len(state.OldDS) > 0 {
+		old := state.OldDS[0]
+		if err := c.registrar.RemoveDS(ctx, state.DomainName, old); err != nil {
+			return fmt.Errorf("remove DS %v: %w", old.Keytag, err)
+		}
+		state.OldDS = state.OldDS[1:]
+		if err := c.store.Save(ctx, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameDS reports whether two DS records describe the same key digest.
+func sameDS(a, b dnsimple.DelegationSignerRecord) bool {
+	return a.Keytag == b.Keytag && a.Algorithm == b.Algorithm &&
+		a.DigestType == b.DigestType && strings.EqualFold(a.Digest, b.Digest)
+}
+
+func appendDS(records []dnsimple.DelegationSignerRecord, ds dnsimple.DelegationSignerRecord) []dnsimple.DelegationSignerRecord {
+	for _, record := range records {
+		if sameDS(record, ds) {
+			return records
+		}
+	}
+	return append(records, ds)
+}
+
+func removeDS(records []dnsimple.DelegationSignerRecord, ds dnsimple.DelegationSignerRecord) []dnsimple.DelegationSignerRecord {
+	var kept []dnsimple.DelegationSignerRecord
+	for _, record := range records {
+		if !sameDS(record, ds) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,50 @@
+package dnssec
+
+import (
+	"context"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple/internal/statestore"
+)
+
+// RolloverStore persists the state of the key rollovers.
+type RolloverStore interface {
+	// Load returns the state of the rollover of the domain, or nil if there is none.
+	Load(ctx context.Context, domainID int64) (*RolloverState, error)
+	// Save stores the state of the rollover of a domain, replacing any previous state.
+	Save(ctx context.Context, state *RolloverState) error
+	// List returns the state of every rollover.
+	List(ctx context.Context) ([]*RolloverState, error)
+}
+
+// FileRolloverStore is a RolloverStore that keeps the states in a JSON file.
+//
+// The file is rewritten atomically on every save. A FileRolloverStore is safe for concurrent use
+// within a process, but the file must not be shared by several processes.
+type FileRolloverStore struct {
+	file *statestore.File[int64, RolloverState]
+}
+
+// NewFileRolloverStore returns a store backed by the file at path.
+// The file is created on the first save.
+func NewFileRolloverStore(path string) *FileRolloverStore {
+	return &FileRolloverStore{file: statestore.NewFile(path, func(state *RolloverState) int64 { return state.DomainID })}
+}
+
+// Load implements RolloverStore.
+func (s *FileRolloverStore) Load(ctx context.Context, domainID int64) (*RolloverState, error) {
+	states, err := s.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	return states[domainID], nil
+}
+
+// Save implements RolloverStore.
+func (s *FileRolloverStore) Save(ctx context.Context, state *RolloverState) error {
+	return s.file.Save(state)
+}
+
+// List implements RolloverStore.
+func (s *FileRolloverStore) List(ctx context.Context) ([]*RolloverState, error) {
+	return s.file.List()
+}
@@ -0,0 +1,167 @@
+package dnssec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/dnsimple/dnsimple-go/dnsimple/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeParentRegistrar struct {
+	published map[string]bool
+	calls     []string
+	fail      error
+}
+
+func (r *fakeParentRegistrar) ListDS(ctx context.Context, domainName string) ([]dnsimple.DelegationSignerRecord, error) {
+	keytags := make([]string, 0, len(r.published))
+	for keytag := range r.published {
+		keytags = append(keytags, keytag)
+	}
+	sort.Strings(keytags)
+
+	records := make([]dnsimple.DelegationSignerRecord, 0, len(keytags))
+	for _, keytag := range keytags {
+		records = append(records, dnsimple.DelegationSignerRecord{Algorithm: "13", Digest: "DIGEST" + keytag, DigestType: "2", Keytag: keytag})
+	}
+	return records, nil
+}
+
+func (r *fakeParentRegistrar) AddDS(ctx context.Context, domainName string, ds dnsimple.DelegationSignerRecord) error {
+	r.calls = append(r.calls, "add "+ds.Keytag)
+	if r.fail != nil {
+		return r.fail
+	}
+	r.published[ds.Keytag] = true
+	return nil
+}
+
+func (r *fakeParentRegistrar) RemoveDS(ctx context.Context, domainName string, ds dnsimple.DelegationSignerRecord) error {
+	r.calls = append(r.calls, "remove "+ds.Keytag)
+	if r.fail != nil {
+		return r.fail
+	}
+	delete(r.published, ds.Keytag)
+	return nil
+}
+
+func rotationEvent(t *testing.T, name string, keytag string) *webhook.Event {
+	payload := fmt.Sprintf(`{"data": {"dnssec": {"enabled": true}, "delegation_signer_record": {"id": 1, "digest": "DIGEST%[2]v", "keytag": "%[2]v", "algorithm": "13", "domain_id": 41557, "digest_type": "2"}}, "name": %[1]q, "actor": {"id": "system", "entity": "dnsimple"}, "account": {"id": 1010, "display": "Personal", "identifier": "foobar"}, "api_version": "v2", "request_identifier": "b2ddc716-f621-418c-a6a9-6a2c2d7acd27"}`, name, keytag)
+	event, err := webhook.ParseEvent([]byte(payload))
+	assert.NoError(t, err)
+	return event
+}
+
+func setupRollover(t *testing.T) (*dnsimple.Client, func()) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/v2/1010/domains/41557", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"id":41557,"name":"example.com"}}`)
+	})
+
+	client := dnsimple.NewClient(http.DefaultClient)
+	client.BaseURL = server.URL
+	return client, server.Close
+}
+
+func TestRolloverCoordinator(t *testing.T) {
+	client, teardown := setupRollover(t)
+	defer teardown()
+
+	// the parent still publishes the DS 50, unknown to DNSimple
+	registrar := &fakeParentRegistrar{published: map[string]bool{"100": true, "50": true}}
+	path := filepath.Join(t.TempDir(), "rollovers.json")
+	store := NewFileRolloverStore(path)
+	coordinator := NewRolloverCoordinator(client, registrar, store)
+	ctx := context.Background()
+
+	assert.NoError(t, coordinator.HandleEvent(ctx, rotationEvent(t, "dnssec.rotation_start", "200")))
+	assert.Equal(t, map[string]bool{"100": true, "200": true, "50": true}, registrar.published)
+
+	state, err := store.Load(ctx, 41557)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", state.DomainName)
+	assert.Equal(t, RolloverStarted, state.Phase)
+	assert.True(t, state.NewDSPublished)
+	assert.Len(t, state.OldDS, 2)
+	assert.Equal(t, "100", state.OldDS[0].Keytag)
+	assert.Equal(t, "50", state.OldDS[1].Keytag)
+
+	// a coordinator restarted with the same store completes the rollover
+	coordinator = NewRolloverCoordinator(client, registrar, NewFileRolloverStore(path))
+	assert.NoError(t, coordinator.HandleEvent(ctx, rotationEvent(t, "dnssec.rotation_complete", "200")))
+	assert.Equal(t, map[string]bool{"200": true}, registrar.published)
+	assert.Equal(t, []string{"add 200", "remove 100", "remove 50"}, registrar.calls)
+
+	state, err = store.Load(ctx, 41557)
+	assert.NoError(t, err)
+	assert.True(t, state.Done())
+}
+
+func TestRolloverCoordinator_MissedStart(t *testing.T) {
+	client, teardown := setupRollover(t)
+	defer teardown()
+
+	registrar := &fakeParentRegistrar{published: map[string]bool{"100": true, "200": true}}
+	store := NewFileRolloverStore(filepath.Join(t.TempDir(), "rollovers.json"))
+	coordinator := NewRolloverCoordinator(client, registrar, store)
+	ctx := context.Background()
+
+	assert.NoError(t, coordinator.HandleEvent(ctx, rotationEvent(t, "dnssec.rotation_complete", "200")))
+	assert.Equal(t, map[string]bool{"200": true}, registrar.published)
+	assert.Equal(t, []string{"add 200", "remove 100"}, registrar.calls)
+
+	state, err := store.Load(ctx, 41557)
+	assert.NoError(t, err)
+	assert.True(t, state.Done())
+}
+
+func TestRolloverCoordinator_IgnoresOtherEvents(t *testing.T) {
+	registrar := &fakeParentRegistrar{published: map[string]bool{}}
+	coordinator := NewRolloverCoordinator(nil, registrar, NewFileRolloverStore(filepath.Join(t.TempDir(), "rollovers.json")))
+
+	assert.NoError(t, coordinator.HandleEvent(context.Background(), rotationEvent(t, "dnssec.create", "200")))
+	assert.Empty(t, registrar.calls)
+}
+
+func TestRolloverCoordinator_Resume(t *testing.T) {
+	client, teardown := setupRollover(t)
+	defer teardown()
+
+	registrar := &fakeParentRegistrar{published: map[string]bool{"100": true}, fail: errors.New("registrar unavailable")}
+	store := NewFileRolloverStore(filepath.Join(t.TempDir(), "rollovers.json"))
+	coordinator := NewRolloverCoordinator(client, registrar, store)
+	ctx := context.Background()
+
+	err := coordinator.HandleEvent(ctx, rotationEvent(t, "dnssec.rotation_start", "200"))
+	assert.EqualError(t, err, "add DS 200: registrar unavailable")
+
+	err = coordinator.HandleEvent(ctx, rotationEvent(t, "dnssec.rotation_complete", "200"))
+	assert.Error(t, err)
+	assert.Equal(t, map[string]bool{"100": true}, registrar.published)
+
+	state, _ := store.Load(ctx, 41557)
+	assert.Equal(t, RolloverCompleted, state.Phase)
+	assert.False(t, state.NewDSPublished)
+
+	// the old DS is never removed before the new one is published
+	assert.NotContains(t, registrar.calls, "remove 100")
+
+	registrar.fail = nil
+	assert.NoError(t, coordinator.Resume(ctx))
+	assert.Equal(t, map[string]bool{"200": true}, registrar.published)
+
+	states, err := store.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, states, 1)
+	assert.True(t, states[0].Done())
+}
@@ -129,3 +129,26 @@ func (s *DomainsService) DeleteDomain(ctx context.Context, accountID string, dom
 	domainResponse.HTTPResponse = resp
 	return domainResponse, nil
 }
+
+// listAllDomains pages through ListDomains and returns the domains from every page.
+func (s *DomainsService) listAllDomains(ctx context.Context, accountID string, options *DomainListOptions) ([]Domain, error) {
+	pageOptions := DomainListOptions{}
+	if options != nil {
+		pageOptions = *options
+	}
+
+	var domains []Domain
+	for page := 1; ; page++ {
+		pageOptions.Page = Int(page)
+
+		domainsResponse, err := s.ListDomains(ctx, accountID, &pageOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		domains = append(domains, domainsResponse.Data...)
+		if domainsResponse.Pagination == nil || page >= domainsResponse.Pagination.TotalPages {
+			return domains, nil
+		}
+	}
+}
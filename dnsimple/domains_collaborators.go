@@ -95,3 +95,19 @@ func (s *DomainsService) RemoveCollaborator(ctx context.Context, accountID strin
 	collaboratorResponse.HTTPResponse = resp
 	return collaboratorResponse, nil
 }
+
+// listAllCollaborators pages through ListCollaborators and returns the collaborators from every page.
+func (s *DomainsService) listAllCollaborators(ctx context.Context, accountID string, domainIdentifier string) ([]Collaborator, error) {
+	var collaborators []Collaborator
+	for page := 1; ; page++ {
+		collaboratorsResponse, err := s.ListCollaborators(ctx, accountID, domainIdentifier, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+
+		collaborators = append(collaborators, collaboratorsResponse.Data...)
+		if collaboratorsResponse.Pagination == nil || page >= collaboratorsResponse.Pagination.TotalPages {
+			return collaborators, nil
+		}
+	}
+}
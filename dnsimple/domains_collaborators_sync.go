@@ -0,0 +1,244 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CollaboratorPolicy declares the collaborators of the domains whose name matches a pattern.
+type CollaboratorPolicy struct {
+	// A shell pattern matched against the domain names, as in path.Match.
+	// Eg. "*" for every domain, "*.io" or "example-*.com".
+	Pattern string
+
+	// The emails of the collaborators of the matching domains.
+	Emails []string
+}
+
+// CollaboratorSyncOptions specifies the optional parameters you can provide
+// to customize the DomainsService.SyncCollaborators method.
+type CollaboratorSyncOptions struct {
+	// The number of domains synced concurrently. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// Only compute the changes, without applying them.
+	DryRun bool
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+}
+
+// CollaboratorDomainReport represents the changes to the collaborators of a domain.
+type CollaboratorDomainReport struct {
+	Domain string `json:"domain"`
+
+	// The emails of the users added as collaborators.
+	Added []string `json:"added"`
+	// The emails that were invited, because they don't belong to a DNSimple user yet.
+	Invited []string `json:"invited"`
+	// The collaborators and invitations removed.
+	Removed []string `json:"removed"`
+	// The desired collaborators whose invitation is still pending.
+	Pending []Collaborator `json:"pending"`
+
+	// The errors of the changes that could not be applied.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// CollaboratorSyncReport represents the outcome of DomainsService.SyncCollaborators.
+// Domains not matched by any policy are not included.
+type CollaboratorSyncReport struct {
+	Domains []CollaboratorDomainReport `json:"domains"`
+}
+
+// Summary returns a one line summary of the report.
+func (r *CollaboratorSyncReport) Summary() string {
+	var added, invited, removed, pending, failed int
+	for _, domain := range r.Domains {
+		added += len(domain.Added)
+		invited += len(domain.Invited)
+		removed += len(domain.Removed)
+		pending += len(domain.Pending)
+		failed += len(domain.Errors)
+	}
+	return fmt.Sprintf("%d domains: %d added, %d invited, %d removed, %d pending invitations, %d errors",
+		len(r.Domains), added, invited, removed, pending, failed)
+}
+
+// Failed reports whether any change could not be applied.
+func (r *CollaboratorSyncReport) Failed() bool {
+	for _, domain := range r.Domains {
+		if len(domain.Errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncCollaborators makes the collaborators of the domains of the account match the policies.
+//
+// The desired collaborators of a domain are the union of the emails of every policy matching
+// its name. Collaborators and pending invitations not desired are removed, missing ones are added.
+// Domains not matched by any policy are left untouched.
+//
+// The domains are synced concurrently. A change that fails is recorded in the report of its domain,
+// and doesn't stop the other changes; an error is returned only if the domains can't be listed.
+func (s *DomainsService) SyncCollaborators(ctx context.Context, accountID string, policies []CollaboratorPolicy, options *CollaboratorSyncOptions) (*CollaboratorSyncReport, error) {
+	syncOptions := CollaboratorSyncOptions{}
+	if options != nil {
+		syncOptions = *options
+	}
+	syncOptions.Concurrency = concurrency(syncOptions.Concurrency)
+
+	for _, policy := range policies {
+		if _, err := path.Match(policy.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid collaborator policy pattern %q: %w", policy.Pattern, err)
+		}
+	}
+
+	domains, err := s.listAllDomains(ctx, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CollaboratorSyncReport{Domains: []CollaboratorDomainReport{}}
+	budget := newRateBudget(int(syncOptions.RateLimitReserve))
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	type job struct {
+		domain  string
+		desired map[string]bool
+	}
+	var pending []job
+	for _, domain := range domains {
+		desired, ok := desiredCollaborators(policies, domain.Name)
+		if ok {
+			pending = append(pending, job{domain: domain.Name, desired: desired})
+		}
+	}
+
+	for i := 0; i < syncOptions.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				domainReport := s.syncDomainCollaborators(ctx, accountID, pending[index].domain, pending[index].desired, syncOptions.DryRun, budget)
+				mu.Lock()
+				report.Domains = append(report.Domains, domainReport)
+				mu.Unlock()
+			}
+		}()
+	}
+	for index := range pending {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(report.Domains, func(i, j int) bool { return report.Domains[i].Domain < report.Domains[j].Domain })
+	return report, nil
+}
+
+// desiredCollaborators returns the set of emails of the policies matching the domain,
+// and whether any policy matched.
+func desiredCollaborators(policies []CollaboratorPolicy, domainName string) (map[string]bool, bool) {
+	desired := map[string]bool{}
+	matched := false
+	for _, policy := range policies {
+		if ok, _ := path.Match(strings.ToLower(policy.Pattern), strings.ToLower(domainName)); !ok {
+			continue
+		}
+		matched = true
+		for _, email := range policy.Emails {
+			desired[strings.ToLower(email)] = true
+		}
+	}
+	return desired, matched
+}
+
+func (s *DomainsService) syncDomainCollaborators(ctx context.Context, accountID string, domainName string, desired map[string]bool, dryRun bool, budget *rateBudget) CollaboratorDomainReport {
+	report := CollaboratorDomainReport{Domain: domainName}
+	fail := func(format string, args ...interface{}) {
+		report.Errors = append(report.Errors, fmt.Sprintf(format, args...))
+	}
+
+	if err := budget.wait(ctx); err != nil {
+		fail("list collaborators: %v", err)
+		return report
+	}
+	existing, err := s.listAllCollaborators(ctx, accountID, domainName)
+	if err != nil {
+		fail("list collaborators: %v", err)
+		return report
+	}
+
+	present := map[string]bool{}
+	for _, collaborator := range existing {
+		email := strings.ToLower(collaborator.UserEmail)
+		if desired[email] && !present[email] {
+			present[email] = true
+			if collaborator.Invitation && collaborator.AcceptedAt == "" {
+				report.Pending = append(report.Pending, collaborator)
+			}
+			continue
+		}
+
+		if dryRun {
+			report.Removed = append(report.Removed, collaborator.UserEmail)
+			continue
+		}
+		if err := budget.wait(ctx); err != nil {
+			fail("remove %v: %v", collaborator.UserEmail, err)
+			continue
+		}
+		collaboratorResponse, err := s.RemoveCollaborator(ctx, accountID, domainName, collaborator.ID)
+		if err != nil && !isNotFound(err) {
+			fail("remove %v: %v", collaborator.UserEmail, err)
+			continue
+		}
+		if collaboratorResponse != nil {
+			budget.update(&collaboratorResponse.Response)
+		}
+		report.Removed = append(report.Removed, collaborator.UserEmail)
+	}
+
+	emails := make([]string, 0, len(desired))
+	for email := range desired {
+		if !present[email] {
+			emails = append(emails, email)
+		}
+	}
+	sort.Strings(emails)
+
+	for _, email := range emails {
+		if dryRun {
+			report.Added = append(report.Added, email)
+			continue
+		}
+		if err := budget.wait(ctx); err != nil {
+			fail("add %v: %v", email, err)
+			continue
+		}
+		collaboratorResponse, err := s.AddCollaborator(ctx, accountID, domainName, CollaboratorAttributes{Email: email})
+		if err != nil {
+			fail("add %v: %v", email, err)
+			continue
+		}
+		budget.update(&collaboratorResponse.Response)
+
+		if collaboratorResponse.Data.Invitation {
+			report.Invited = append(report.Invited, email)
+			report.Pending = append(report.Pending, *collaboratorResponse.Data)
+		} else {
+			report.Added = append(report.Added, email)
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,149 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainsService_SyncCollaborators(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	var mu sync.Mutex
+	collaborators := map[string][]Collaborator{
+		"example.com": {
+			{ID: 1, UserEmail: "jane@example.net", AcceptedAt: "2016-10-07T08:53:41Z"},
+			{ID: 2, UserEmail: "leaver@example.net", AcceptedAt: "2016-10-07T08:53:41Z"},
+			{ID: 3, UserEmail: "new@example.net", Invitation: true},
+		},
+		"example.io": {},
+		"other.org":  {{ID: 4, UserEmail: "leaver@example.net"}},
+	}
+	var calls []string
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"name":"example.com"},{"name":"example.io"},{"name":"other.org"}],"pagination":{"current_page":1,"per_page":30,"total_entries":3,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/1010/domains/"), "/")
+		domain := parts[0]
+		switch r.Method {
+		case "GET":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": collaborators[domain], "pagination": Pagination{CurrentPage: 1, TotalPages: 1}})
+		case "POST":
+			var attributes CollaboratorAttributes
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+			calls = append(calls, "add "+domain+" "+attributes.Email)
+			if attributes.Email == "broken@example.net" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"message":"Validation failed"}`)
+				return
+			}
+			collaborator := Collaborator{ID: 10, UserEmail: attributes.Email, Invitation: attributes.Email == "new@example.net"}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": collaborator})
+		case "DELETE":
+			calls = append(calls, "remove "+domain+" "+parts[2])
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	policies := []CollaboratorPolicy{
+		{Pattern: "*", Emails: []string{"Jane@example.net"}},
+		{Pattern: "example.*", Emails: []string{"new@example.net"}},
+		{Pattern: "*.io", Emails: []string{"broken@example.net"}},
+	}
+
+	report, err := client.Domains.SyncCollaborators(context.Background(), "1010", policies, &CollaboratorSyncOptions{Concurrency: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Domains, 3)
+
+	com := report.Domains[0]
+	assert.Equal(t, "example.com", com.Domain)
+	assert.Empty(t, com.Added)
+	assert.Equal(t, []string{"leaver@example.net"}, com.Removed)
+	assert.Len(t, com.Pending, 1)
+	assert.Equal(t, int64(3), com.Pending[0].ID)
+
+	io := report.Domains[1]
+	assert.Equal(t, "example.io", io.Domain)
+	assert.Equal(t, []string{"jane@example.net"}, io.Added)
+	assert.Equal(t, []string{"new@example.net"}, io.Invited)
+	assert.Len(t, io.Pending, 1)
+	assert.Len(t, io.Errors, 1)
+	assert.Contains(t, io.Errors[0], "add broken@example.net")
+
+	org := report.Domains[2]
+	assert.Equal(t, []string{"jane@example.net"}, org.Added)
+	assert.Equal(t, []string{"leaver@example.net"}, org.Removed)
+
+	assert.True(t, report.Failed())
+	assert.Equal(t, "3 domains: 2 added, 1 invited, 2 removed, 2 pending invitations, 1 errors", report.Summary())
+	assert.Contains(t, calls, "remove example.com 2")
+	assert.Contains(t, calls, "remove other.org 4")
+}
+
+func TestDomainsService_SyncCollaborators_RemoveFailed(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"name":"example.com"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/example.com/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":2,"user_email":"leaver@example.net"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/example.com/collaborators/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"Collaborator can't be removed"}`)
+	})
+
+	report, err := client.Domains.SyncCollaborators(context.Background(), "1010", []CollaboratorPolicy{{Pattern: "*.com"}}, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Domains[0].Removed)
+	assert.Len(t, report.Domains[0].Errors, 1)
+	assert.Contains(t, report.Domains[0].Errors[0], "remove leaver@example.net")
+}
+
+func TestDomainsService_SyncCollaborators_DryRun(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"name":"example.com"},{"name":"other.org"}],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/example.com/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[{"id":1,"user_email":"leaver@example.net"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+
+	report, err := client.Domains.SyncCollaborators(context.Background(), "1010", []CollaboratorPolicy{{Pattern: "*.com", Emails: []string{"jane@example.net"}}}, &CollaboratorSyncOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Domains, 1)
+	assert.Equal(t, []string{"jane@example.net"}, report.Domains[0].Added)
+	assert.Equal(t, []string{"leaver@example.net"}, report.Domains[0].Removed)
+	assert.False(t, report.Failed())
+}
+
+func TestDomainsService_SyncCollaborators_InvalidPattern(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	_, err := client.Domains.SyncCollaborators(context.Background(), "1010", []CollaboratorPolicy{{Pattern: "[a-"}}, nil)
+
+	assert.Error(t, err)
+}
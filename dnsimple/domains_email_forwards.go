@@ -88,6 +88,22 @@ func (s *DomainsService) GetEmailForward(ctx context.Context, accountID string,
 	return forwardResponse, nil
 }
 
+// UpdateEmailForward updates an email forward.
+//
+// See https://developer.dnsimple.com/v2/domains/email-forwards/#update
+func (s *DomainsService) UpdateEmailForward(ctx context.Context, accountID string, domainIdentifier string, forwardID int64, forwardAttributes EmailForward) (*EmailForwardResponse, error) {
+	path := versioned(emailForwardPath(accountID, domainIdentifier, forwardID))
+	forwardResponse := &EmailForwardResponse{}
+
+	resp, err := s.client.patch(ctx, path, forwardAttributes, forwardResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardResponse.HTTPResponse = resp
+	return forwardResponse, nil
+}
+
 // DeleteEmailForward PERMANENTLY deletes an email forward from the domain.
 //
 // See https://developer.dnsimple.com/v2/domains/email-forwards/#delete
@@ -103,3 +119,19 @@ func (s *DomainsService) DeleteEmailForward(ctx context.Context, accountID strin
 	forwardResponse.HTTPResponse = resp
 	return forwardResponse, nil
 }
+
+// listAllEmailForwards pages through ListEmailForwards and returns the email forwards from every page.
+func (s *DomainsService) listAllEmailForwards(ctx context.Context, accountID string, domainIdentifier string) ([]EmailForward, error) {
+	var forwards []EmailForward
+	for page := 1; ; page++ {
+		forwardsResponse, err := s.ListEmailForwards(ctx, accountID, domainIdentifier, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+
+		forwards = append(forwards, forwardsResponse.Data...)
+		if forwardsResponse.Pagination == nil || page >= forwardsResponse.Pagination.TotalPages {
+			return forwards, nil
+		}
+	}
+}
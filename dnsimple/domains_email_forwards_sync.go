@@ -0,0 +1,222 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EmailForwardCatchAll is the alias that forwards every address of the domain
+// not matched by another forward.
+const EmailForwardCatchAll = "*"
+
+// EmailForwardSyncOptions specifies the optional parameters you can provide
+// to customize the DomainsService.SyncEmailForwards method.
+type EmailForwardSyncOptions struct {
+	// Only compute the changes, without applying them.
+	DryRun bool
+
+	// Keep the existing forwards whose alias is not in the desired map,
+	// instead of removing them.
+	KeepUnlisted bool
+}
+
+// EmailForwardChange represents an email forward whose destination was changed.
+type EmailForwardChange struct {
+	Forward EmailForward
+	// The destination before the change.
+	PreviousTo string
+}
+
+// EmailForwardSyncResult represents the changes applied by DomainsService.SyncEmailForwards.
+type EmailForwardSyncResult struct {
+	Created []EmailForward
+	Changed []EmailForwardChange
+	Removed []EmailForward
+}
+
+// Empty reports whether the sync found no change to apply.
+func (r *EmailForwardSyncResult) Empty() bool {
+	return len(r.Created) == 0 && len(r.Changed) == 0 && len(r.Removed) == 0
+}
+
+// EmailForwardValidationError is returned when the desired email forwards contain invalid addresses.
+type EmailForwardValidationError struct {
+	// The problem of each invalid entry, sorted by alias.
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *EmailForwardValidationError) Error() string {
+	return "invalid email forwards: " + strings.Join(e.Problems, "; ")
+}
+
+// SyncEmailForwards makes the email forwards of the domain match the desired map of alias to destination.
+//
+// An alias is either a local part, such as "info", or an address in the domain, such as "info@example.com".
+// The EmailForwardCatchAll alias forwards the addresses of the domain not matched by another forward.
+// Every alias and destination is validated before any change is applied; an invalid entry
+// returns an *EmailForwardValidationError.
+//
+// Forwards for aliases not in the map are removed, unless KeepUnlisted is set.
+// When an alias has several forwards, the extra ones are removed.
+func (s *DomainsService) SyncEmailForwards(ctx context.Context, accountID string, domainName string, desired map[string]string, options *EmailForwardSyncOptions) (*EmailForwardSyncResult, error) {
+	syncOptions := EmailForwardSyncOptions{}
+	if options != nil {
+		syncOptions = *options
+	}
+
+	wanted, err := normalizeEmailForwards(domainName, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.listAllEmailForwards(ctx, accountID, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EmailForwardSyncResult{}
+	seen := map[string]bool{}
+	for _, forward := range existing {
+		from := normalizeEmailForwardAlias(forward.From, domainName)
+		to, ok := wanted[from]
+		switch {
+		case seen[from] || (!ok && !syncOptions.KeepUnlisted):
+			result.Removed = append(result.Removed, forward)
+		case ok && !strings.EqualFold(forward.To, to):
+			updated := forward
+			updated.To = to
+			result.Changed = append(result.Changed, EmailForwardChange{Forward: updated, PreviousTo: forward.To})
+		}
+		seen[from] = true
+	}
+
+	aliases := make([]string, 0, len(wanted))
+	for from := range wanted {
+		aliases = append(aliases, from)
+	}
+	sort.Strings(aliases)
+	for _, from := range aliases {
+		if !seen[from] {
+			result.Created = append(result.Created, EmailForward{From: from, To: wanted[from]})
+		}
+	}
+
+	if syncOptions.DryRun {
+		return result, nil
+	}
+
+	// removing first frees the aliases of duplicates before creating new forwards
+	for _, forward := range result.Removed {
+		if _, err := s.DeleteEmailForward(ctx, accountID, domainName, forward.ID); err != nil && !isNotFound(err) {
+			return result, err
+		}
+	}
+	for i, change := range result.Changed {
+		forwardResponse, err := s.UpdateEmailForward(ctx, accountID, domainName, change.Forward.ID, EmailForward{To: change.Forward.To})
+		if err != nil {
+			return result, err
+		}
+		result.Changed[i].Forward = *forwardResponse.Data
+	}
+	for i, forward := range result.Created {
+		forwardResponse, err := s.CreateEmailForward(ctx, accountID, domainName, forward)
+		if err != nil {
+			return result, err
+		}
+		result.Created[i] = *forwardResponse.Data
+	}
+
+	return result, nil
+}
+
+// normalizeEmailForwards validates the desired forwards and keys them by the full alias address.
+func normalizeEmailForwards(domainName string, desired map[string]string) (map[string]string, error) {
+	wanted := map[string]string{}
+	var problems []string
+	for alias, to := range desired {
+		if err := validateEmailForwardAlias(alias, domainName); err != nil {
+			problems = append(problems, fmt.Sprintf("alias %q: %v", alias, err))
+			continue
+		}
+		if err := validateEmailAddress(to); err != nil {
+			problems = append(problems, fmt.Sprintf("alias %q: destination %q: %v", alias, to, err))
+			continue
+		}
+
+		from := normalizeEmailForwardAlias(alias, domainName)
+		if _, ok := wanted[from]; ok {
+			problems = append(problems, fmt.Sprintf("alias %q: duplicate of another alias", alias))
+			continue
+		}
+		wanted[from] = to
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, &EmailForwardValidationError{Problems: problems}
+	}
+	return wanted, nil
+}
+
+// normalizeEmailForwardAlias returns the address of the alias in the domain, as used in EmailForward.From.
+// The catch-all is represented as ".*@domain".
+func normalizeEmailForwardAlias(alias string, domainName string) string {
+	local := alias
+	if i := strings.LastIndex(alias, "@"); i >= 0 {
+		local = alias[:i]
+	}
+	if local == EmailForwardCatchAll {
+		local = ".*"
+	}
+	return strings.ToLower(local + "@" + domainName)
+}
+
+func validateEmailForwardAlias(alias string, domainName string) error {
+	local := alias
+	if i := strings.LastIndex(alias, "@"); i >= 0 {
+		local = alias[:i]
+		if !strings.EqualFold(alias[i+1:], domainName) {
+			return fmt.Errorf("not an address of %v", domainName)
+		}
+	}
+	if local == EmailForwardCatchAll || local == ".*" {
+		return nil
+	}
+	return validateEmailLocalPart(local)
+}
+
+// validateEmailAddress checks that address is an email address with a dot-atom local part
+// and a fully qualified domain (RFC 5322, section 3.4.1).
+func validateEmailAddress(address string) error {
+	i := strings.LastIndex(address, "@")
+	if i < 0 {
+		return fmt.Errorf("missing @")
+	}
+	if err := validateEmailLocalPart(address[:i]); err != nil {
+		return err
+	}
+	if err := validateHostname(address[i+1:]); err != nil || strings.HasSuffix(address, ".") {
+		return fmt.Errorf("invalid domain %q", address[i+1:])
+	}
+	return nil
+}
+
+func validateEmailLocalPart(local string) error {
+	if local == "" || len(local) > 64 {
+		return fmt.Errorf("invalid local part %q", local)
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("invalid local part %q", local)
+		}
+		for _, c := range atom {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("!#$%&'*+/=?^_`{|}~-", c)) {
+				return fmt.Errorf("invalid local part %q", local)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeEmailForwards is an in-memory implementation of the email forwards endpoints of a domain.
+type fakeEmailForwards struct {
+	mu       sync.Mutex
+	nextID   int64
+	forwards []EmailForward
+}
+
+func (f *fakeEmailForwards) register(t *testing.T, accountID string, domainName string) {
+	base := "/v2/" + accountID + "/domains/" + domainName + "/email_forwards"
+
+	mux.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.Method {
+		case "GET":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.forwards, "pagination": Pagination{CurrentPage: 1, TotalPages: 1}})
+		case "POST":
+			var forward EmailForward
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&forward))
+			f.nextID++
+			forward.ID = f.nextID
+			f.forwards = append(f.forwards, forward)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": forward})
+		}
+	})
+
+	mux.HandleFunc(base+"/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, base+"/"), 10, 64)
+		for i := range f.forwards {
+			if f.forwards[i].ID != id {
+				continue
+			}
+			switch r.Method {
+			case "PATCH":
+				var attributes EmailForward
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+				f.forwards[i].To = attributes.To
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.forwards[i]})
+			case "DELETE":
+				f.forwards = append(f.forwards[:i], f.forwards[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Email forward not found"}`))
+	})
+}
+
+func TestDomainsService_SyncEmailForwards(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeEmailForwards{nextID: 10, forwards: []EmailForward{
+		{ID: 1, From: "info@example.com", To: "old@example.net"},
+		{ID: 2, From: "sales@example.com", To: "sales@example.net"},
+		{ID: 3, From: "legacy@example.com", To: "legacy@example.net"},
+		{ID: 4, From: "Sales@example.com", To: "sales@example.net"},
+	}}
+	fake.register(t, "1010", "example.com")
+
+	desired := map[string]string{
+		"info":              "team@example.net",
+		"sales@example.com": "sales@example.net",
+		"*":                 "catchall@example.net",
+	}
+
+	result, err := client.Domains.SyncEmailForwards(context.Background(), "1010", "example.com", desired, &EmailForwardSyncOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Len(t, fake.forwards, 4)
+	assert.Len(t, result.Created, 1)
+	assert.Len(t, result.Changed, 1)
+	assert.Len(t, result.Removed, 2)
+
+	result, err = client.Domains.SyncEmailForwards(context.Background(), "1010", "example.com", desired, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []EmailForward{{ID: 11, From: ".*@example.com", To: "catchall@example.net"}}, result.Created)
+	assert.Equal(t, []EmailForwardChange{{Forward: EmailForward{ID: 1, From: "info@example.com", To: "team@example.net"}, PreviousTo: "old@example.net"}}, result.Changed)
+	assert.Equal(t, []int64{3, 4}, []int64{result.Removed[0].ID, result.Removed[1].ID})
+	assert.Len(t, fake.forwards, 3)
+
+	result, err = client.Domains.SyncEmailForwards(context.Background(), "1010", "example.com", desired, nil)
+	assert.NoError(t, err)
+	assert.True(t, result.Empty())
+}
+
+func TestDomainsService_SyncEmailForwards_KeepUnlisted(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeEmailForwards{nextID: 10, forwards: []EmailForward{{ID: 1, From: "legacy@example.com", To: "legacy@example.net"}}}
+	fake.register(t, "1010", "example.com")
+
+	result, err := client.Domains.SyncEmailForwards(context.Background(), "1010", "example.com", map[string]string{"info": "team@example.net"}, &EmailForwardSyncOptions{KeepUnlisted: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Removed)
+	assert.Len(t, fake.forwards, 2)
+}
+
+func TestDomainsService_SyncEmailForwards_Invalid(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	desired := map[string]string{
+		"info":             "not-an-email",
+		"bad alias":        "team@example.net",
+		"info@example.org": "team@example.net",
+		"ok":               "team@localhost",
+	}
+
+	_, err := client.Domains.SyncEmailForwards(context.Background(), "1010", "example.com", desired, nil)
+
+	var validationErr *EmailForwardValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{
+		`alias "bad alias": invalid local part "bad alias"`,
+		`alias "info": destination "not-an-email": missing @`,
+		`alias "info@example.org": not an address of example.com`,
+		`alias "ok": destination "team@localhost": invalid domain "localhost"`,
+	}, validationErr.Problems)
+}
+
+func TestValidateEmailAddress(t *testing.T) {
+	assert.NoError(t, validateEmailAddress("jane.smith+dns@example.com"))
+	assert.Error(t, validateEmailAddress("jane..smith@example.com"))
+	assert.Error(t, validateEmailAddress("@example.com"))
+	assert.Error(t, validateEmailAddress("jane@example.com."))
+	assert.Error(t, validateEmailAddress("jane@-example.com"))
+}
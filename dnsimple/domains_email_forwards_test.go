@@ -114,6 +114,32 @@ func TestDomainsService_GetEmailForward(t *testing.T) {
 	assert.Equal(t, wantSingle, forward)
 }
 
+func TestDomainsService_UpdateEmailForward(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains/example.com/email_forwards/41872", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/updateEmailForward/success.http")
+
+		testMethod(t, r, "PATCH")
+		testHeaders(t, r)
+
+		want := map[string]interface{}{"to": "new@example.com"}
+		testRequestJSON(t, r, want)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	forwardAttributes := EmailForward{To: "new@example.com"}
+
+	forwardResponse, err := client.Domains.UpdateEmailForward(context.Background(), "1010", "example.com", 41872, forwardAttributes)
+	assert.NoError(t, err)
+	forward := forwardResponse.Data
+	assert.Equal(t, int64(41872), forward.ID)
+	assert.Equal(t, "new@example.com", forward.To)
+}
+
 func TestDomainsService_DeleteEmailForward(t *testing.T) {
 	setupMockServer()
 	defer teardownMockServer()
@@ -0,0 +1,229 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// DomainExpiryReportOptions specifies the optional parameters you can provide
+// to customize DomainsService.GenerateDomainExpiryReport.
+type DomainExpiryReportOptions struct {
+	// The windows, in days from now, used to classify expiring domains. They must be positive.
+	// Defaults to 30, 60 and 90 days. Domains expiring after the largest window
+	// are not included in the report.
+	Windows []int
+
+	// Include domains with auto-renewal enabled in the report.
+	// They are never flagged, but they are accounted in the renewal cost.
+	IncludeAutoRenew bool
+
+	// Skip fetching the renewal prices.
+	SkipPrices bool
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+
+	// The reference time of the report. Defaults to the current time.
+	Now time.Time
+}
+
+// DomainExpiry represents a domain in a DomainExpiryReport.
+type DomainExpiry struct {
+	Domain    string    `json:"domain"`
+	ExpiresAt time.Time `json:"expires_at"`
+	AutoRenew bool      `json:"auto_renew"`
+
+	// The number of whole days until the domain expires, rounded down:
+	// negative once the domain has expired, -1 during the first day after the expiration.
+	DaysLeft int `json:"days_left"`
+
+	// The smallest window, in days, the expiration falls in.
+	Window int `json:"window"`
+
+	// Flagged is true when the domain expires within a window without auto-renewal.
+	Flagged bool `json:"flagged"`
+
+	// The renewal price of the domain, and the error preventing to fetch it if any.
+	RenewalPrice float64 `json:"renewal_price"`
+	PriceError   string  `json:"price_error,omitempty"`
+
+	// The error parsing the expiration of the domain, if any. The other fields
+	// are then left empty, and the domain is not accounted in the renewal cost.
+	ExpirationError string `json:"expiration_error,omitempty"`
+}
+
+// DomainRenewalMonth represents the estimated renewal cost of the domains expiring in a month.
+type DomainRenewalMonth struct {
+	// The month, in the YYYY-MM format.
+	Month   string  `json:"month"`
+	Domains int     `json:"domains"`
+	Cost    float64 `json:"cost"`
+}
+
+// DomainExpiryReport represents the domains of an account expiring within a set of windows,
+// and the renewal cost per month.
+type DomainExpiryReport struct {
+	GeneratedAt time.Time            `json:"generated_at"`
+	Windows     []int                `json:"windows"`
+	Domains     []DomainExpiry       `json:"domains"`
+	Months      []DomainRenewalMonth `json:"months"`
+}
+
+// Flagged returns the domains expiring within a window without auto-renewal.
+func (r *DomainExpiryReport) Flagged() []DomainExpiry {
+	var flagged []DomainExpiry
+	for _, domain := range r.Domains {
+		if domain.Flagged {
+			flagged = append(flagged, domain)
+		}
+	}
+	return flagged
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *DomainExpiryReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the domains of the report to w as CSV, with a header row.
+func (r *DomainExpiryReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"domain", "expires_at", "days_left", "window", "auto_renew", "flagged", "renewal_price", "price_error", "expiration_error"})
+	for _, domain := range r.Domains {
+		_ = writer.Write([]string{
+			domain.Domain,
+			domain.ExpiresAt.Format(time.RFC3339),
+			strconv.Itoa(domain.DaysLeft),
+			strconv.Itoa(domain.Window),
+			strconv.FormatBool(domain.AutoRenew),
+			strconv.FormatBool(domain.Flagged),
+			strconv.FormatFloat(domain.RenewalPrice, 'f', 2, 64),
+			domain.PriceError,
+			domain.ExpirationError,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// GenerateDomainExpiryReport pages through the domains of the account,
+// and reports the ones expiring within the largest window.
+//
+// Unless SkipPrices is set, the renewal price of each reported domain is fetched
+// with RegistrarService.GetDomainPrices to estimate the renewal cost per month.
+// A failure to fetch the price of a domain is recorded in the domain PriceError.
+//
+// A domain with an invalid expiration is reported, first, with the error in ExpirationError.
+func (s *DomainsService) GenerateDomainExpiryReport(ctx context.Context, accountID string, options *DomainExpiryReportOptions) (*DomainExpiryReport, error) {
+	reportOptions := DomainExpiryReportOptions{}
+	if options != nil {
+		reportOptions = *options
+	}
+	windows := append([]int(nil), reportOptions.Windows...)
+	if len(windows) == 0 {
+		windows = []int{30, 60, 90}
+	}
+	sort.Ints(windows)
+	if windows[0] <= 0 {
+		return nil, fmt.Errorf("invalid window %d: windows must be positive", windows[0])
+	}
+	now := reportOptions.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	domains, err := s.listAllDomains(ctx, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DomainExpiryReport{GeneratedAt: now, Windows: windows, Domains: []DomainExpiry{}, Months: []DomainRenewalMonth{}}
+	for _, domain := range domains {
+		if domain.ExpiresAt == "" || (domain.AutoRenew && !reportOptions.IncludeAutoRenew) {
+			continue
+		}
+		expiresAt, err := time.Parse(time.RFC3339, domain.ExpiresAt)
+		if err != nil {
+			report.Domains = append(report.Domains, DomainExpiry{
+				Domain:          domain.Name,
+				AutoRenew:       domain.AutoRenew,
+				ExpirationError: fmt.Sprintf("invalid expiration %q: %v", domain.ExpiresAt, err),
+			})
+			continue
+		}
+
+		daysLeft := int(math.Floor(expiresAt.Sub(now).Hours() / 24))
+		window := expiryWindow(windows, daysLeft)
+		if window == 0 {
+			continue
+		}
+
+		report.Domains = append(report.Domains, DomainExpiry{
+			Domain:    domain.Name,
+			ExpiresAt: expiresAt,
+			AutoRenew: domain.AutoRenew,
+			DaysLeft:  daysLeft,
+			Window:    window,
+			Flagged:   !domain.AutoRenew,
+		})
+	}
+	sort.SliceStable(report.Domains, func(i, j int) bool {
+		return report.Domains[i].ExpiresAt.Before(report.Domains[j].ExpiresAt)
+	})
+
+	if !reportOptions.SkipPrices {
+		budget := newRateBudget(int(reportOptions.RateLimitReserve))
+		for i := range report.Domains {
+			if report.Domains[i].ExpirationError != "" {
+				continue
+			}
+			if err := budget.wait(ctx); err != nil {
+				return nil, err
+			}
+			pricesResponse, err := s.client.Registrar.GetDomainPrices(ctx, accountID, report.Domains[i].Domain)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				report.Domains[i].PriceError = err.Error()
+				continue
+			}
+			budget.update(&pricesResponse.Response)
+			report.Domains[i].RenewalPrice = pricesResponse.Data.RenewalPrice
+		}
+	}
+
+	for _, domain := range report.Domains {
+		if domain.ExpirationError != "" {
+			continue
+		}
+		month := domain.ExpiresAt.UTC().Format("2006-01")
+		if n := len(report.Months); n == 0 || report.Months[n-1].Month != month {
+			report.Months = append(report.Months, DomainRenewalMonth{Month: month})
+		}
+		report.Months[len(report.Months)-1].Domains++
+		report.Months[len(report.Months)-1].Cost += domain.RenewalPrice
+	}
+
+	return report, nil
+}
+
+// expiryWindow returns the smallest window that includes daysLeft, or 0 if none does.
+// Expired domains fall in the smallest window.
+func expiryWindow(windows []int, daysLeft int) int {
+	for _, window := range windows {
+		if daysLeft <= window {
+			return window
+		}
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package dnsimple
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainsService_GenerateDomainExpiryReport(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"data":[
+				{"name":"soon.com","auto_renew":false,"expires_at":"2026-11-01T00:00:00Z"},
+				{"name":"renewing.com","auto_renew":true,"expires_at":"2026-11-02T00:00:00Z"},
+				{"name":"hosted.com","auto_renew":false,"expires_at":null}
+			],"pagination":{"current_page":1,"per_page":3,"total_entries":5,"total_pages":2}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[
+				{"name":"later.com","auto_renew":false,"expires_at":"2026-12-20T00:00:00Z"},
+				{"name":"far.com","auto_renew":false,"expires_at":"2027-06-01T00:00:00Z"}
+			],"pagination":{"current_page":2,"per_page":3,"total_entries":5,"total_pages":2}}`)
+		}
+	})
+	mux.HandleFunc("/v2/1010/registrar/domains/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		domain := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/1010/registrar/domains/"), "/prices")
+		if domain == "later.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"TLD .com is not supported"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data":{"domain":%q,"premium":false,"registration_price":10.0,"renewal_price":12.5,"transfer_price":10.0}}`, domain)
+	})
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	report, err := client.Domains.GenerateDomainExpiryReport(context.Background(), "1010", &DomainExpiryReportOptions{Now: now, IncludeAutoRenew: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{30, 60, 90}, report.Windows)
+	assert.Len(t, report.Domains, 3)
+
+	soon := report.Domains[0]
+	assert.Equal(t, "soon.com", soon.Domain)
+	assert.Equal(t, 14, soon.DaysLeft)
+	assert.Equal(t, 30, soon.Window)
+	assert.True(t, soon.Flagged)
+	assert.Equal(t, 12.5, soon.RenewalPrice)
+
+	assert.Equal(t, "renewing.com", report.Domains[1].Domain)
+	assert.False(t, report.Domains[1].Flagged)
+
+	later := report.Domains[2]
+	assert.Equal(t, 90, later.Window)
+	assert.Contains(t, later.PriceError, "TLD .com is not supported")
+
+	assert.Equal(t, []DomainRenewalMonth{{Month: "2026-11", Domains: 2, Cost: 25}, {Month: "2026-12", Domains: 1, Cost: 0}}, report.Months)
+	assert.Len(t, report.Flagged(), 2)
+}
+
+func TestDomainsService_GenerateDomainExpiryReport_SkipAutoRenew(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"name":"renewing.com","auto_renew":true,"expires_at":"2026-11-02T00:00:00Z"},
+			{"name":"expired.com","auto_renew":false,"expires_at":"2026-10-01T00:00:00Z"},
+			{"name":"yesterday.com","auto_renew":false,"expires_at":"2026-10-17T12:00:00Z"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":3,"total_pages":1}}`)
+	})
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	report, err := client.Domains.GenerateDomainExpiryReport(context.Background(), "1010", &DomainExpiryReportOptions{Now: now, Windows: []int{7}, SkipPrices: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Domains, 2)
+	assert.Equal(t, "expired.com", report.Domains[0].Domain)
+	assert.Equal(t, -17, report.Domains[0].DaysLeft)
+	assert.Equal(t, 7, report.Domains[0].Window)
+	assert.Equal(t, "yesterday.com", report.Domains[1].Domain)
+	assert.Equal(t, -1, report.Domains[1].DaysLeft)
+}
+
+func TestDomainsService_GenerateDomainExpiryReport_InvalidExpiration(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"name":"soon.com","auto_renew":false,"expires_at":"2026-11-01T00:00:00Z"},
+			{"name":"broken.com","auto_renew":false,"expires_at":"2026-11-01"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	report, err := client.Domains.GenerateDomainExpiryReport(context.Background(), "1010", &DomainExpiryReportOptions{Now: now, SkipPrices: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Domains, 2)
+	assert.Equal(t, "broken.com", report.Domains[0].Domain)
+	assert.Contains(t, report.Domains[0].ExpirationError, `invalid expiration "2026-11-01"`)
+	assert.Equal(t, "soon.com", report.Domains[1].Domain)
+	assert.Empty(t, report.Domains[1].ExpirationError)
+	assert.Equal(t, []DomainRenewalMonth{{Month: "2026-11", Domains: 1}}, report.Months)
+}
+
+func TestDomainsService_GenerateDomainExpiryReport_InvalidWindows(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	_, err := client.Domains.GenerateDomainExpiryReport(context.Background(), "1010", &DomainExpiryReportOptions{Windows: []int{30, 0}})
+
+	assert.EqualError(t, err, "invalid window 0: windows must be positive")
+}
+
+func TestDomainExpiryReport_Write(t *testing.T) {
+	report := &DomainExpiryReport{
+		GeneratedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Windows:     []int{30},
+		Domains: []DomainExpiry{
+			{Domain: "soon.com", ExpiresAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), DaysLeft: 14, Window: 30, Flagged: true, RenewalPrice: 12.5},
+		},
+		Months: []DomainRenewalMonth{{Month: "2026-11", Domains: 1, Cost: 12.5}},
+	}
+
+	var csvOut bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&csvOut))
+	assert.Equal(t, "domain,expires_at,days_left,window,auto_renew,flagged,renewal_price,price_error,expiration_error\n"+
+		"soon.com,2026-11-01T00:00:00Z,14,30,false,true,12.50,,\n", csvOut.String())
+
+	var jsonOut bytes.Buffer
+	assert.NoError(t, report.WriteJSON(&jsonOut))
+	assert.Contains(t, jsonOut.String(), `"month": "2026-11"`)
+	assert.Contains(t, jsonOut.String(), `"flagged": true`)
+}
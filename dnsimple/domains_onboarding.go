@@ -0,0 +1,442 @@
+package dnsimple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DomainOnboardingSpec declares the desired setup of a domain onboarded with DomainOnboarding.
+type DomainOnboardingSpec struct {
+	// The name of the domain to add to the account.
+	Name string
+
+	// The templates to apply, by ID or short name.
+	Templates []string
+
+	// The one-click services to apply.
+	Services []DomainOnboardingService
+
+	// Enable DNSSEC for the domain.
+	EnableDnssec bool
+
+	// The email forwards to create. A From without the @ is qualified with the domain name.
+	EmailForwards []EmailForward
+
+	// The emails of the collaborators to add.
+	Collaborators []string
+}
+
+// DomainOnboardingService represents a one-click service to apply in a DomainOnboardingSpec.
+type DomainOnboardingService struct {
+	// The service ID or short name.
+	Service  string
+	Settings map[string]string
+}
+
+// DomainOnboardingStepKind identifies the action of a DomainOnboardingStep.
+type DomainOnboardingStepKind string
+
+// The kinds of onboarding steps, in the order they run.
+const (
+	DomainOnboardingCreateDomain       DomainOnboardingStepKind = "create_domain"
+	DomainOnboardingApplyTemplate      DomainOnboardingStepKind = "apply_template"
+	DomainOnboardingApplyService       DomainOnboardingStepKind = "apply_service"
+	DomainOnboardingEnableDnssec       DomainOnboardingStepKind = "enable_dnssec"
+	DomainOnboardingCreateEmailForward DomainOnboardingStepKind = "create_email_forward"
+	DomainOnboardingAddCollaborator    DomainOnboardingStepKind = "add_collaborator"
+)
+
+// DomainOnboardingStep represents a step of the onboarding of a domain.
+type DomainOnboardingStep struct {
+	Kind DomainOnboardingStepKind
+	// The object of the step: the template, the service, the email forward source
+	// or the collaborator email. Empty for the domain and DNSSEC steps.
+	Target string
+}
+
+func (s DomainOnboardingStep) String() string {
+	if s.Target == "" {
+		return string(s.Kind)
+	}
+	return fmt.Sprintf("%v %v", s.Kind, s.Target)
+}
+
+// DomainOnboardingStatus represents the state of a step reported to the progress callback.
+type DomainOnboardingStatus string
+
+// The states of an onboarding step.
+const (
+	DomainOnboardingStarted    DomainOnboardingStatus = "started"
+	DomainOnboardingDone       DomainOnboardingStatus = "done"
+	DomainOnboardingSkipped    DomainOnboardingStatus = "skipped"
+	DomainOnboardingFailed     DomainOnboardingStatus = "failed"
+	DomainOnboardingUndone     DomainOnboardingStatus = "undone"
+	DomainOnboardingUndoFailed DomainOnboardingStatus = "undo_failed"
+)
+
+// DomainOnboardingProgress represents a change in the state of a step.
+type DomainOnboardingProgress struct {
+	Step   DomainOnboardingStep
+	Status DomainOnboardingStatus
+	// The error of a failed step, or of a failed undo.
+	Err error
+}
+
+// DomainOnboardingResult represents the outcome of each step of an onboarding.
+type DomainOnboardingResult struct {
+	Domain *Domain
+	// The final state of each step, in the order they ran.
+	Steps []DomainOnboardingProgress
+}
+
+// DomainOnboardingError is returned when a step of the onboarding fails.
+type DomainOnboardingError struct {
+	Step DomainOnboardingStep
+	Err  error
+	// The errors of the steps that could not be undone, if the undo was requested.
+	UndoErrors []error
+}
+
+// Error implements the error interface.
+func (e *DomainOnboardingError) Error() string {
+	message := fmt.Sprintf("onboarding step %v failed: %v", e.Step, e.Err)
+	if len(e.UndoErrors) > 0 {
+		message += fmt.Sprintf(" (%d steps could not be undone)", len(e.UndoErrors))
+	}
+	return message
+}
+
+// Unwrap returns the error of the failed step.
+func (e *DomainOnboardingError) Unwrap() error {
+	return e.Err
+}
+
+// DomainOnboarding adds a domain to an account and sets it up according to a DomainOnboardingSpec.
+//
+// Each step checks the current state of the domain first, and is skipped if already done,
+// so that an onboarding can be run again after a failure, or against a domain already set up.
+type DomainOnboarding struct {
+	client    *Client
+	accountID string
+
+	// Progress, if set, is called when a step starts, and when it completes, is skipped, fails or is undone.
+	Progress func(DomainOnboardingProgress)
+
+	// UndoOnFailure reverts the steps done by a failed onboarding, in reverse order.
+	// Steps that were skipped because already done are never reverted.
+	UndoOnFailure bool
+}
+
+// NewDomainOnboarding returns an onboarding of domains in the account.
+func NewDomainOnboarding(client *Client, accountID string) *DomainOnboarding {
+	return &DomainOnboarding{client: client, accountID: accountID}
+}
+
+// Plan returns the steps needed to onboard the domain described by the spec.
+func (o *DomainOnboarding) Plan(spec DomainOnboardingSpec) []DomainOnboardingStep {
+	steps := []DomainOnboardingStep{{Kind: DomainOnboardingCreateDomain}}
+	for _, template := range spec.Templates {
+		steps = append(steps, DomainOnboardingStep{Kind: DomainOnboardingApplyTemplate, Target: template})
+	}
+	for _, service := range spec.Services {
+		steps = append(steps, DomainOnboardingStep{Kind: DomainOnboardingApplyService, Target: service.Service})
+	}
+	if spec.EnableDnssec {
+		steps = append(steps, DomainOnboardingStep{Kind: DomainOnboardingEnableDnssec})
+	}
+	for _, forward := range spec.EmailForwards {
+		steps = append(steps, DomainOnboardingStep{Kind: DomainOnboardingCreateEmailForward, Target: qualifiedEmail(forward.From, spec.Name)})
+	}
+	for _, email := range spec.Collaborators {
+		steps = append(steps, DomainOnboardingStep{Kind: DomainOnboardingAddCollaborator, Target: email})
+	}
+	return steps
+}
+
+// onboardingRun holds the state of a single onboarding.
+type onboardingRun struct {
+	*DomainOnboarding
+	spec   DomainOnboardingSpec
+	result *DomainOnboardingResult
+	// the functions reverting the steps done so far
+	undo []onboardingUndo
+}
+
+type onboardingUndo struct {
+	step DomainOnboardingStep
+	fn   func(ctx context.Context) error
+}
+
+// Run onboards the domain described by the spec.
+//
+// When a step fails, the remaining steps are not run and a *DomainOnboardingError is returned,
+// together with the result of the steps run so far.
+func (o *DomainOnboarding) Run(ctx context.Context, spec DomainOnboardingSpec) (*DomainOnboardingResult, error) {
+	if spec.Name == "" {
+		return nil, errors.New("onboarding spec requires a domain name")
+	}
+
+	run := &onboardingRun{DomainOnboarding: o, spec: spec, result: &DomainOnboardingResult{}}
+	services := map[string]DomainOnboardingService{}
+	for _, service := range spec.Services {
+		services[service.Service] = service
+	}
+	forwards := map[string]EmailForward{}
+	for _, forward := range spec.EmailForwards {
+		forwards[qualifiedEmail(forward.From, spec.Name)] = forward
+	}
+
+	for _, step := range o.Plan(spec) {
+		o.report(DomainOnboardingProgress{Step: step, Status: DomainOnboardingStarted})
+
+		var done bool
+		var err error
+		switch step.Kind {
+		case DomainOnboardingCreateDomain:
+			done, err = run.createDomain(ctx, step)
+		case DomainOnboardingApplyTemplate:
+			done, err = run.applyTemplate(ctx, step)
+		case DomainOnboardingApplyService:
+			done, err = run.applyService(ctx, step, services[step.Target])
+		case DomainOnboardingEnableDnssec:
+			done, err = run.enableDnssec(ctx, step)
+		case DomainOnboardingCreateEmailForward:
+			done, err = run.createEmailForward(ctx, step, forwards[step.Target])
+		case DomainOnboardingAddCollaborator:
+			done, err = run.addCollaborator(ctx, step)
+		}
+
+		if err != nil {
+			run.finish(DomainOnboardingProgress{Step: step, Status: DomainOnboardingFailed, Err: err})
+			onboardingErr := &DomainOnboardingError{Step: step, Err: err}
+			if o.UndoOnFailure {
+				onboardingErr.UndoErrors = run.rollback(ctx)
+			}
+			return run.result, onboardingErr
+		}
+
+		status := DomainOnboardingDone
+		if !done {
+			status = DomainOnboardingSkipped
+		}
+		run.finish(DomainOnboardingProgress{Step: step, Status: status})
+	}
+
+	return run.result, nil
+}
+
+func (o *DomainOnboarding) report(progress DomainOnboardingProgress) {
+	if o.Progress != nil {
+		o.Progress(progress)
+	}
+}
+
+func (r *onboardingRun) finish(progress DomainOnboardingProgress) {
+	r.result.Steps = append(r.result.Steps, progress)
+	r.report(progress)
+}
+
+// rollback reverts the steps done so far in reverse order, and returns the errors of the failed reverts.
+// The undo runs even if ctx is canceled, so that the domain is not left half configured.
+func (r *onboardingRun) rollback(ctx context.Context) []error {
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	var errs []error
+	for i := len(r.undo) - 1; i >= 0; i-- {
+		undo := r.undo[i]
+		status := DomainOnboardingUndone
+		err := undo.fn(ctx)
+		if err != nil {
+			status = DomainOnboardingUndoFailed
+			errs = append(errs, fmt.Errorf("undo %v: %w", undo.step, err))
+		}
+		r.finish(DomainOnboardingProgress{Step: undo.step, Status: status, Err: err})
+	}
+	return errs
+}
+
+func (r *onboardingRun) onUndo(step DomainOnboardingStep, fn func(ctx context.Context) error) {
+	r.undo = append(r.undo, onboardingUndo{step: step, fn: fn})
+}
+
+func (r *onboardingRun) createDomain(ctx context.Context, step DomainOnboardingStep) (bool, error) {
+	domainResponse, err := r.client.Domains.GetDomain(ctx, r.accountID, r.spec.Name)
+	if err == nil {
+		r.result.Domain = domainResponse.Data
+		return false, nil
+	}
+	if !isNotFound(err) {
+		return false, err
+	}
+
+	domainResponse, err = r.client.Domains.CreateDomain(ctx, r.accountID, Domain{Name: r.spec.Name})
+	if err != nil {
+		return false, err
+	}
+	r.result.Domain = domainResponse.Data
+	r.onUndo(step, func(ctx context.Context) error {
+		_, err := r.client.Domains.DeleteDomain(ctx, r.accountID, r.spec.Name)
+		return err
+	})
+	return true, nil
+}
+
+// applyTemplate applies the template, unless every record of the template is already in the zone.
+// The records added by the template are the ones reverted by the undo.
+func (r *onboardingRun) applyTemplate(ctx context.Context, step DomainOnboardingStep) (bool, error) {
+	templateRecords, err := r.client.Templates.listAllTemplateRecords(ctx, r.accountID, step.Target)
+	if err != nil {
+		return false, err
+	}
+	before, err := r.client.Zones.listAllRecords(ctx, r.accountID, r.spec.Name, nil)
+	if err != nil {
+		return false, err
+	}
+
+	existing := map[string]bool{}
+	for _, record := range before {
+		existing[onboardingRecordKey(record.Name, record.Type, record.Content)] = true
+	}
+	applied := len(templateRecords) > 0
+	for _, record := range templateRecords {
+		if !existing[onboardingRecordKey(record.Name, record.Type, record.Content)] {
+			applied = false
+		}
+	}
+	if applied {
+		return false, nil
+	}
+
+	if _, err := r.client.Templates.ApplyTemplate(ctx, r.accountID, step.Target, r.spec.Name); err != nil {
+		return false, err
+	}
+
+	after, err := r.client.Zones.listAllRecords(ctx, r.accountID, r.spec.Name, nil)
+	if err != nil {
+		return false, err
+	}
+	previous := map[int64]bool{}
+	for _, record := range before {
+		previous[record.ID] = true
+	}
+	var added []int64
+	for _, record := range after {
+		if !previous[record.ID] && !record.SystemRecord {
+			added = append(added, record.ID)
+		}
+	}
+
+	r.onUndo(step, func(ctx context.Context) error {
+		for _, recordID := range added {
+			if _, err := r.client.Zones.DeleteRecord(ctx, r.accountID, r.spec.Name, recordID); err != nil && !isNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	})
+	return true, nil
+}
+
+func onboardingRecordKey(name, recordType, content string) string {
+	return strings.ToLower(name) + " " + strings.ToUpper(recordType) + " " + content
+}
+
+func (r *onboardingRun) applyService(ctx context.Context, step DomainOnboardingStep, service DomainOnboardingService) (bool, error) {
+	applied, err := r.client.Services.listAllAppliedServices(ctx, r.accountID, r.spec.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range applied {
+		if existing.SID == service.Service || strconv.FormatInt(existing.ID, 10) == service.Service {
+			return false, nil
+		}
+	}
+
+	settings := DomainServiceSettings{Settings: service.Settings}
+	if _, err := r.client.Services.ApplyService(ctx, r.accountID, service.Service, r.spec.Name, settings); err != nil {
+		return false, err
+	}
+	r.onUndo(step, func(ctx context.Context) error {
+		_, err := r.client.Services.UnapplyService(ctx, r.accountID, service.Service, r.spec.Name)
+		return err
+	})
+	return true, nil
+}
+
+func (r *onboardingRun) enableDnssec(ctx context.Context, step DomainOnboardingStep) (bool, error) {
+	dnssecResponse, err := r.client.Domains.GetDnssec(ctx, r.accountID, r.spec.Name)
+	if err != nil {
+		return false, err
+	}
+	if dnssecResponse.Data != nil && dnssecResponse.Data.Enabled {
+		return false, nil
+	}
+
+	if _, err := r.client.Domains.EnableDnssec(ctx, r.accountID, r.spec.Name); err != nil {
+		return false, err
+	}
+	r.onUndo(step, func(ctx context.Context) error {
+		_, err := r.client.Domains.DisableDnssec(ctx, r.accountID, r.spec.Name)
+		return err
+	})
+	return true, nil
+}
+
+func (r *onboardingRun) createEmailForward(ctx context.Context, step DomainOnboardingStep, forward EmailForward) (bool, error) {
+	existing, err := r.client.Domains.listAllEmailForwards(ctx, r.accountID, r.spec.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, current := range existing {
+		if strings.EqualFold(current.From, step.Target) && strings.EqualFold(current.To, forward.To) {
+			return false, nil
+		}
+	}
+
+	forwardResponse, err := r.client.Domains.CreateEmailForward(ctx, r.accountID, r.spec.Name, EmailForward{From: step.Target, To: forward.To})
+	if err != nil {
+		return false, err
+	}
+	forwardID := forwardResponse.Data.ID
+	r.onUndo(step, func(ctx context.Context) error {
+		_, err := r.client.Domains.DeleteEmailForward(ctx, r.accountID, r.spec.Name, forwardID)
+		return err
+	})
+	return true, nil
+}
+
+func (r *onboardingRun) addCollaborator(ctx context.Context, step DomainOnboardingStep) (bool, error) {
+	existing, err := r.client.Domains.listAllCollaborators(ctx, r.accountID, r.spec.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, collaborator := range existing {
+		if strings.EqualFold(collaborator.UserEmail, step.Target) {
+			return false, nil
+		}
+	}
+
+	collaboratorResponse, err := r.client.Domains.AddCollaborator(ctx, r.accountID, r.spec.Name, CollaboratorAttributes{Email: step.Target})
+	if err != nil {
+		return false, err
+	}
+	collaboratorID := collaboratorResponse.Data.ID
+	r.onUndo(step, func(ctx context.Context) error {
+		_, err := r.client.Domains.RemoveCollaborator(ctx, r.accountID, r.spec.Name, collaboratorID)
+		return err
+	})
+	return true, nil
+}
+
+// qualifiedEmail appends the domain to an email local part without the @.
+func qualifiedEmail(address string, domainName string) string {
+	if strings.Contains(address, "@") {
+		return address
+	}
+	return address + "@" + domainName
+}
@@ -0,0 +1,239 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOnboardingDomain is an in-memory implementation of the endpoints used to onboard example.com.
+type fakeOnboardingDomain struct {
+	mu               sync.Mutex
+	exists           bool
+	records          []ZoneRecord
+	services         []Service
+	dnssec           bool
+	forwards         []EmailForward
+	collaborators    []Collaborator
+	failCollaborator bool
+	nextID           int64
+	calls            []string
+}
+
+func (f *fakeOnboardingDomain) id() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *fakeOnboardingDomain) register(t *testing.T) {
+	list := func(w http.ResponseWriter, data interface{}) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "pagination": Pagination{CurrentPage: 1, TotalPages: 1}})
+	}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Domain not found"}`)
+	}
+
+	mux.HandleFunc("/v2/1010/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/v2/1010")
+		call := r.Method + " " + path
+		if r.Method != "GET" {
+			f.calls = append(f.calls, call)
+		}
+
+		switch {
+		case call == "GET /domains/example.com":
+			if !f.exists {
+				notFound(w)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"id":1,"name":"example.com"}}`)
+		case call == "POST /domains":
+			f.exists = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"id":1,"name":"example.com"}}`)
+		case call == "DELETE /domains/example.com":
+			f.exists = false
+			w.WriteHeader(http.StatusNoContent)
+		case call == "GET /templates/web/records":
+			list(w, []TemplateRecord{{Name: "", Type: "A", Content: "192.0.2.1"}, {Name: "www", Type: "CNAME", Content: "example.com"}})
+		case call == "GET /zones/example.com/records":
+			list(w, f.records)
+		case call == "POST /domains/example.com/templates/web":
+			f.records = append(f.records,
+				ZoneRecord{ID: f.id(), Name: "", Type: "A", Content: "192.0.2.1"},
+				ZoneRecord{ID: f.id(), Name: "www", Type: "CNAME", Content: "example.com"})
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(call, "DELETE /zones/example.com/records/"):
+			for i, record := range f.records {
+				if path == fmt.Sprintf("/zones/example.com/records/%d", record.ID) {
+					f.records = append(f.records[:i], f.records[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case call == "GET /domains/example.com/services":
+			list(w, f.services)
+		case call == "POST /domains/example.com/services/wordpress":
+			f.services = append(f.services, Service{ID: 2, SID: "wordpress"})
+			w.WriteHeader(http.StatusNoContent)
+		case call == "DELETE /domains/example.com/services/wordpress":
+			f.services = nil
+			w.WriteHeader(http.StatusNoContent)
+		case call == "GET /domains/example.com/dnssec":
+			fmt.Fprintf(w, `{"data":{"enabled":%v}}`, f.dnssec)
+		case call == "POST /domains/example.com/dnssec":
+			f.dnssec = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":{"enabled":true}}`)
+		case call == "DELETE /domains/example.com/dnssec":
+			f.dnssec = false
+			w.WriteHeader(http.StatusNoContent)
+		case call == "GET /domains/example.com/email_forwards":
+			list(w, f.forwards)
+		case call == "POST /domains/example.com/email_forwards":
+			var forward EmailForward
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&forward))
+			forward.ID = f.id()
+			f.forwards = append(f.forwards, forward)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": forward})
+		case strings.HasPrefix(call, "DELETE /domains/example.com/email_forwards/"):
+			f.forwards = nil
+			w.WriteHeader(http.StatusNoContent)
+		case call == "GET /domains/example.com/collaborators":
+			list(w, f.collaborators)
+		case call == "POST /domains/example.com/collaborators":
+			if f.failCollaborator {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"message":"Validation failed"}`)
+				return
+			}
+			var attributes CollaboratorAttributes
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+			collaborator := Collaborator{ID: f.id(), UserEmail: attributes.Email}
+			f.collaborators = append(f.collaborators, collaborator)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": collaborator})
+		default:
+			t.Errorf("unexpected request %v", call)
+			notFound(w)
+		}
+	})
+}
+
+func onboardingSpec() DomainOnboardingSpec {
+	return DomainOnboardingSpec{
+		Name:          "example.com",
+		Templates:     []string{"web"},
+		Services:      []DomainOnboardingService{{Service: "wordpress"}},
+		EnableDnssec:  true,
+		EmailForwards: []EmailForward{{From: "info", To: "team@example.net"}},
+		Collaborators: []string{"jane@example.net"},
+	}
+}
+
+func TestDomainOnboarding_Plan(t *testing.T) {
+	steps := NewDomainOnboarding(nil, "1010").Plan(onboardingSpec())
+
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.String())
+	}
+	assert.Equal(t, []string{
+		"create_domain",
+		"apply_template web",
+		"apply_service wordpress",
+		"enable_dnssec",
+		"create_email_forward info@example.com",
+		"add_collaborator jane@example.net",
+	}, names)
+}
+
+func TestDomainOnboarding_Run(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeOnboardingDomain{}
+	fake.register(t)
+
+	var progress []string
+	onboarding := NewDomainOnboarding(client, "1010")
+	onboarding.Progress = func(p DomainOnboardingProgress) {
+		progress = append(progress, fmt.Sprintf("%v: %v", p.Step, p.Status))
+	}
+
+	result, err := onboarding.Run(context.Background(), onboardingSpec())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", result.Domain.Name)
+	assert.Len(t, result.Steps, 6)
+	for _, step := range result.Steps {
+		assert.Equal(t, DomainOnboardingDone, step.Status, step.Step.String())
+	}
+	assert.Equal(t, "create_domain: started", progress[0])
+	assert.Equal(t, "create_domain: done", progress[1])
+	assert.Equal(t, "info@example.com", fake.forwards[0].From)
+	assert.True(t, fake.dnssec)
+
+	// running again skips every step
+	fake.calls = nil
+	result, err = onboarding.Run(context.Background(), onboardingSpec())
+
+	assert.NoError(t, err)
+	for _, step := range result.Steps {
+		assert.Equal(t, DomainOnboardingSkipped, step.Status, step.Step.String())
+	}
+	assert.Empty(t, fake.calls)
+}
+
+func TestDomainOnboarding_UndoOnFailure(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeOnboardingDomain{exists: true, failCollaborator: true}
+	fake.register(t)
+
+	onboarding := NewDomainOnboarding(client, "1010")
+	onboarding.UndoOnFailure = true
+
+	result, err := onboarding.Run(context.Background(), onboardingSpec())
+
+	var onboardingErr *DomainOnboardingError
+	assert.ErrorAs(t, err, &onboardingErr)
+	assert.Equal(t, DomainOnboardingAddCollaborator, onboardingErr.Step.Kind)
+	assert.Empty(t, onboardingErr.UndoErrors)
+	assert.Contains(t, err.Error(), "onboarding step add_collaborator jane@example.net failed")
+
+	var statuses []string
+	for _, step := range result.Steps {
+		statuses = append(statuses, fmt.Sprintf("%v: %v", step.Step.Kind, step.Status))
+	}
+	assert.Equal(t, []string{
+		"create_domain: skipped",
+		"apply_template: done",
+		"apply_service: done",
+		"enable_dnssec: done",
+		"create_email_forward: done",
+		"add_collaborator: failed",
+		"create_email_forward: undone",
+		"enable_dnssec: undone",
+		"apply_service: undone",
+		"apply_template: undone",
+	}, statuses)
+
+	assert.True(t, fake.exists)
+	assert.Empty(t, fake.records)
+	assert.Empty(t, fake.services)
+	assert.False(t, fake.dnssec)
+	assert.Empty(t, fake.forwards)
+}
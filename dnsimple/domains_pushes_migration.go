@@ -0,0 +1,386 @@
+package dnsimple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple/internal/statestore"
+)
+
+// DomainMigrationStatus represents the progress of the migration of a domain.
+type DomainMigrationStatus string
+
+// The states of the migration of a domain.
+const (
+	DomainMigrationPending   DomainMigrationStatus = "pending"
+	DomainMigrationInitiated DomainMigrationStatus = "initiated"
+	DomainMigrationAccepted  DomainMigrationStatus = "accepted"
+	DomainMigrationFailed    DomainMigrationStatus = "failed"
+	DomainMigrationCanceled  DomainMigrationStatus = "canceled"
+)
+
+// DomainMigrationState represents the progress of the migration of a domain between accounts.
+type DomainMigrationState struct {
+	Domain         string                `json:"domain"`
+	Status         DomainMigrationStatus `json:"status"`
+	SourceDomainID int64                 `json:"source_domain_id,omitempty"`
+	PushID         int64                 `json:"push_id,omitempty"`
+	// The contact of the target account the push was accepted with.
+	ContactID int64 `json:"contact_id,omitempty"`
+	// The error of the last attempt, for failed migrations.
+	Error string `json:"error,omitempty"`
+}
+
+// DomainMigrationStore persists the progress of a DomainMigration.
+type DomainMigrationStore interface {
+	// Load returns the state of every domain, keyed by domain name.
+	Load(ctx context.Context) (map[string]*DomainMigrationState, error)
+	// Save stores the state of a domain, replacing any previous state.
+	Save(ctx context.Context, state *DomainMigrationState) error
+}
+
+// DomainMigration moves domains from a source account to a target account with domain pushes:
+// the push is initiated with the source client, and accepted with the target client.
+//
+// The progress of each domain is saved to the Store after every step. Running a migration
+// again resumes the domains that are not accepted yet, so an interrupted migration
+// can be run again with the same store.
+type DomainMigration struct {
+	source          *Client
+	sourceAccountID string
+	target          *Client
+	targetAccountID string
+
+	// The email of the target account, used to initiate the pushes.
+	TargetAccountEmail string
+
+	// ContactMap maps the IDs of the registrant contacts in the source account
+	// to the IDs of the contacts to use in the target account.
+	//
+	// Registrants without an entry are matched with the target contact with the same
+	// email, first name, last name and organization, or copied to the target account
+	// when CreateMissingContacts is set.
+	ContactMap map[int64]int64
+
+	// Copy the registrant contacts not found in the target account.
+	CreateMissingContacts bool
+
+	// The contact of the target account to accept the pushes of domains without registrant.
+	DefaultContactID int64
+
+	// The store of the progress. Defaults to an in-memory store.
+	Store DomainMigrationStore
+
+	// Progress, if set, is called every time the state of a domain changes.
+	Progress func(DomainMigrationState)
+
+	targetContacts []Contact
+}
+
+// NewDomainMigration returns a migration from the source account to the target account.
+// Each client must be authenticated for its account.
+func NewDomainMigration(source *Client, sourceAccountID string, target *Client, targetAccountID string, targetAccountEmail string) *DomainMigration {
+	return &DomainMigration{
+		source:             source,
+		sourceAccountID:    sourceAccountID,
+		target:             target,
+		targetAccountID:    targetAccountID,
+		TargetAccountEmail: targetAccountEmail,
+		ContactMap:         map[int64]int64{},
+		Store:              &memoryDomainMigrationStore{states: statestore.NewMemory(domainMigrationKey)},
+	}
+}
+
+// Run migrates the domains, one at a time, and returns the state of each of them.
+//
+// A domain that fails is recorded as failed and doesn't stop the migration of the others;
+// running the migration again retries it. The canceled domains are skipped until they are resumed.
+// An error is returned only if the store fails or ctx is canceled.
+func (m *DomainMigration) Run(ctx context.Context, domainNames []string) ([]DomainMigrationState, error) {
+	states, err := m.Store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DomainMigrationState, 0, len(domainNames))
+	for _, name := range domainNames {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		state := states[name]
+		if state == nil {
+			state = &DomainMigrationState{Domain: name, Status: DomainMigrationPending}
+		}
+		if state.Status != DomainMigrationAccepted && state.Status != DomainMigrationCanceled {
+			if err := m.migrate(ctx, state); err != nil {
+				return results, err
+			}
+		}
+		results = append(results, *state)
+	}
+	return results, nil
+}
+
+// Cancel stops the migration of a domain, rejecting its push in the target account if initiated.
+func (m *DomainMigration) Cancel(ctx context.Context, domainName string) error {
+	states, err := m.Store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	state := states[domainName]
+	if state == nil {
+		return fmt.Errorf("domain %v is not being migrated", domainName)
+	}
+	if state.Status == DomainMigrationAccepted {
+		return fmt.Errorf("domain %v has already been migrated", domainName)
+	}
+
+	if state.PushID != 0 {
+		if _, err := m.target.Domains.RejectPush(ctx, m.targetAccountID, state.PushID); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+	state.Status = DomainMigrationCanceled
+	state.PushID = 0
+	state.Error = ""
+	return m.save(ctx, state)
+}
+
+// Resume restarts the migration of a canceled domain on the next Run.
+func (m *DomainMigration) Resume(ctx context.Context, domainName string) error {
+	states, err := m.Store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	state := states[domainName]
+	if state == nil || state.Status != DomainMigrationCanceled {
+		return fmt.Errorf("domain %v is not canceled", domainName)
+	}
+
+	state.Status = DomainMigrationPending
+	return m.save(ctx, state)
+}
+
+// migrate advances the migration of a domain as far as possible.
+// Only the errors of the store and of the context are returned,
+// the other errors are recorded in the state.
+func (m *DomainMigration) migrate(ctx context.Context, state *DomainMigrationState) error {
+	err := m.advance(ctx, state)
+	if err == nil {
+		return nil
+	}
+	var storeErr *domainMigrationStoreError
+	if errors.As(err, &storeErr) {
+		return storeErr.err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	state.Status = DomainMigrationFailed
+	state.Error = err.Error()
+	return m.save(ctx, state)
+}
+
+func (m *DomainMigration) advance(ctx context.Context, state *DomainMigrationState) error {
+	if state.SourceDomainID == 0 || state.ContactID == 0 {
+		domainResponse, err := m.source.Domains.GetDomain(ctx, m.sourceAccountID, state.Domain)
+		if err != nil {
+			return fmt.Errorf("get source domain: %w", err)
+		}
+		state.SourceDomainID = domainResponse.Data.ID
+
+		contactID, err := m.targetContactID(ctx, domainResponse.Data)
+		if err != nil {
+			return err
+		}
+		state.ContactID = contactID
+	}
+
+	push, err := m.findPush(ctx, state)
+	if err != nil {
+		return err
+	}
+	if push == nil {
+		if state.PushID != 0 {
+			// the push is gone from the target account: it was accepted, unless it was rejected
+			if _, err := m.target.Domains.GetDomain(ctx, m.targetAccountID, state.Domain); err == nil {
+				state.Status = DomainMigrationAccepted
+				state.Error = ""
+				return m.save(ctx, state)
+			}
+		}
+
+		pushResponse, err := m.source.Domains.InitiatePush(ctx, m.sourceAccountID, state.Domain, DomainPushAttributes{NewAccountEmail: m.TargetAccountEmail})
+		if err != nil {
+			return fmt.Errorf("initiate push: %w", err)
+		}
+		push = pushResponse.Data
+	}
+
+	state.PushID = push.ID
+	state.Status = DomainMigrationInitiated
+	state.Error = ""
+	if err := m.save(ctx, state); err != nil {
+		return err
+	}
+
+	if _, err := m.target.Domains.AcceptPush(ctx, m.targetAccountID, state.PushID, DomainPushAttributes{ContactID: state.ContactID}); err != nil {
+		return fmt.Errorf("accept push: %w", err)
+	}
+	state.Status = DomainMigrationAccepted
+	return m.save(ctx, state)
+}
+
+// findPush returns the pending push of the domain in the target account, if any.
+func (m *DomainMigration) findPush(ctx context.Context, state *DomainMigrationState) (*DomainPush, error) {
+	for page := 1; ; page++ {
+		pushesResponse, err := m.target.Domains.ListPushes(ctx, m.targetAccountID, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, fmt.Errorf("list pushes: %w", err)
+		}
+		for _, push := range pushesResponse.Data {
+			if push.AcceptedAt != "" {
+				continue
+			}
+			if (state.PushID != 0 && push.ID == state.PushID) || push.DomainID == state.SourceDomainID {
+				found := push
+				return &found, nil
+			}
+		}
+		if pushesResponse.Pagination == nil || page >= pushesResponse.Pagination.TotalPages {
+			return nil, nil
+		}
+	}
+}
+
+// targetContactID returns the contact of the target account to accept the push of the domain with.
+func (m *DomainMigration) targetContactID(ctx context.Context, domain *Domain) (int64, error) {
+	registrantID := domain.RegistrantID
+	if registrantID == 0 {
+		if m.DefaultContactID == 0 {
+			return 0, fmt.Errorf("domain %v has no registrant and no default contact is set", domain.Name)
+		}
+		return m.DefaultContactID, nil
+	}
+	if contactID, ok := m.ContactMap[registrantID]; ok {
+		return contactID, nil
+	}
+
+	contactResponse, err := m.source.Contacts.GetContact(ctx, m.sourceAccountID, registrantID)
+	if err != nil {
+		return 0, fmt.Errorf("get source contact: %w", err)
+	}
+	registrant := *contactResponse.Data
+
+	if m.targetContacts == nil {
+		contacts, err := m.listTargetContacts(ctx)
+		if err != nil {
+			return 0, err
+		}
+		m.targetContacts = contacts
+	}
+	for _, contact := range m.targetContacts {
+		if sameContact(contact, registrant) {
+			m.ContactMap[registrantID] = contact.ID
+			return contact.ID, nil
+		}
+	}
+
+	if !m.CreateMissingContacts {
+		return 0, fmt.Errorf("no contact of the target account matches the registrant %v (%v)", registrantID, registrant.Email)
+	}
+	copied := registrant
+	copied.ID = 0
+	copied.AccountID = 0
+	copied.CreatedAt = ""
+	copied.UpdatedAt = ""
+	created, err := m.target.Contacts.CreateContact(ctx, m.targetAccountID, copied)
+	if err != nil {
+		return 0, fmt.Errorf("create target contact: %w", err)
+	}
+	m.targetContacts = append(m.targetContacts, *created.Data)
+	m.ContactMap[registrantID] = created.Data.ID
+	return created.Data.ID, nil
+}
+
+func (m *DomainMigration) listTargetContacts(ctx context.Context) ([]Contact, error) {
+	contacts := []Contact{}
+	for page := 1; ; page++ {
+		contactsResponse, err := m.target.Contacts.ListContacts(ctx, m.targetAccountID, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, fmt.Errorf("list target contacts: %w", err)
+		}
+		contacts = append(contacts, contactsResponse.Data...)
+		if contactsResponse.Pagination == nil || page >= contactsResponse.Pagination.TotalPages {
+			return contacts, nil
+		}
+	}
+}
+
+func sameContact(a, b Contact) bool {
+	return strings.EqualFold(a.Email, b.Email) &&
+		strings.EqualFold(a.FirstName, b.FirstName) &&
+		strings.EqualFold(a.LastName, b.LastName) &&
+		strings.EqualFold(a.Organization, b.Organization)
+}
+
+func (m *DomainMigration) save(ctx context.Context, state *DomainMigrationState) error {
+	if err := m.Store.Save(ctx, state); err != nil {
+		return &domainMigrationStoreError{err: err}
+	}
+	if m.Progress != nil {
+		m.Progress(*state)
+	}
+	return nil
+}
+
+// domainMigrationStoreError wraps the errors of the store, that stop the migration.
+type domainMigrationStoreError struct {
+	err error
+}
+
+func (e *domainMigrationStoreError) Error() string {
+	return e.err.Error()
+}
+
+func domainMigrationKey(state *DomainMigrationState) string {
+	return state.Domain
+}
+
+type memoryDomainMigrationStore struct {
+	states *statestore.Memory[string, DomainMigrationState]
+}
+
+func (s *memoryDomainMigrationStore) Load(ctx context.Context) (map[string]*DomainMigrationState, error) {
+	return s.states.Load()
+}
+
+func (s *memoryDomainMigrationStore) Save(ctx context.Context, state *DomainMigrationState) error {
+	return s.states.Save(state)
+}
+
+// FileDomainMigrationStore is a DomainMigrationStore that keeps the states in a JSON file,
+// rewritten atomically on every save.
+type FileDomainMigrationStore struct {
+	file *statestore.File[string, DomainMigrationState]
+}
+
+// NewFileDomainMigrationStore returns a store backed by the file at path.
+// The file is created on the first save.
+func NewFileDomainMigrationStore(path string) *FileDomainMigrationStore {
+	return &FileDomainMigrationStore{file: statestore.NewFile(path, domainMigrationKey)}
+}
+
+// Load implements DomainMigrationStore.
+func (s *FileDomainMigrationStore) Load(ctx context.Context) (map[string]*DomainMigrationState, error) {
+	return s.file.Load()
+}
+
+// Save implements DomainMigrationStore.
+func (s *FileDomainMigrationStore) Save(ctx context.Context, state *DomainMigrationState) error {
+	return s.file.Save(state)
+}
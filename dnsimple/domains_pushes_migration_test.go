@@ -0,0 +1,230 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePushAccounts is an in-memory implementation of the endpoints used to push domains
+// from the account 1010 to the account 2020.
+type fakePushAccounts struct {
+	mu         sync.Mutex
+	domains    map[string]Domain
+	moved      map[string]bool
+	pushes     []DomainPush
+	nextPushID int64
+	contacts   []Contact
+	failAccept map[int64]bool
+	initiated  []string
+	accepted   map[int64]int64
+}
+
+func (f *fakePushAccounts) register(t *testing.T) {
+	mux.HandleFunc("/v2/1010/domains/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/1010/domains/"), "/")
+		domain, ok := f.domains[parts[0]]
+		if !ok || f.moved[domain.Name] {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Domain not found"}`)
+			return
+		}
+		if len(parts) == 1 {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": domain})
+			return
+		}
+
+		testMethod(t, r, "POST")
+		testRequestJSON(t, r, map[string]interface{}{"new_account_email": "target@example.com"})
+		f.initiated = append(f.initiated, domain.Name)
+		f.nextPushID++
+		push := DomainPush{ID: f.nextPushID, DomainID: domain.ID, AccountID: 2020}
+		f.pushes = append(f.pushes, push)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": push})
+	})
+	mux.HandleFunc("/v2/1010/contacts/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/v2/1010/contacts/")
+		switch id {
+		case "5":
+			fmt.Fprint(w, `{"data":{"id":5,"first_name":"Jane","last_name":"Smith","email":"jane@example.com"}}`)
+		case "6":
+			fmt.Fprint(w, `{"data":{"id":6,"first_name":"Bob","last_name":"Jones","email":"bob@example.com","account_id":1010}}`)
+		}
+	})
+	mux.HandleFunc("/v2/2020/contacts", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.Method == "POST" {
+			var contact Contact
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&contact))
+			assert.Zero(t, contact.AccountID)
+			contact.ID = 60
+			f.contacts = append(f.contacts, contact)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": contact})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.contacts, "pagination": Pagination{CurrentPage: 1, TotalPages: 1}})
+	})
+	mux.HandleFunc("/v2/2020/pushes", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.pushes, "pagination": Pagination{CurrentPage: 1, TotalPages: 1}})
+	})
+	mux.HandleFunc("/v2/2020/pushes/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/v2/2020/pushes/"), 10, 64)
+		if f.failAccept[id] {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"Temporary failure"}`)
+			return
+		}
+		var attributes DomainPushAttributes
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+		for i, push := range f.pushes {
+			if push.ID == id {
+				f.accepted[id] = attributes.ContactID
+				f.pushes = append(f.pushes[:i], f.pushes[i+1:]...)
+				for name, domain := range f.domains {
+					if domain.ID == push.DomainID {
+						f.moved[name] = true
+					}
+				}
+				break
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/v2/2020/domains/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		name := strings.TrimPrefix(r.URL.Path, "/v2/2020/domains/")
+		if !f.moved[name] {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Domain not found"}`)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": Domain{Name: name}})
+	})
+}
+
+func TestDomainMigration_Run(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakePushAccounts{
+		domains: map[string]Domain{
+			"a.com": {ID: 100, Name: "a.com", RegistrantID: 5},
+			"b.com": {ID: 101, Name: "b.com", RegistrantID: 6},
+			"c.com": {ID: 102, Name: "c.com"},
+		},
+		moved:      map[string]bool{},
+		contacts:   []Contact{{ID: 50, FirstName: "jane", LastName: "smith", Email: "JANE@example.com"}},
+		failAccept: map[int64]bool{2: true},
+		accepted:   map[int64]int64{},
+	}
+	fake.register(t)
+
+	storePath := filepath.Join(t.TempDir(), "migration.json")
+	migration := NewDomainMigration(client, "1010", client, "2020", "target@example.com")
+	migration.Store = NewFileDomainMigrationStore(storePath)
+	migration.CreateMissingContacts = true
+	migration.DefaultContactID = 70
+	var progress []string
+	migration.Progress = func(state DomainMigrationState) {
+		progress = append(progress, state.Domain+" "+string(state.Status))
+	}
+
+	states, err := migration.Run(context.Background(), []string{"a.com", "b.com", "c.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, DomainMigrationAccepted, states[0].Status)
+	assert.Equal(t, int64(50), states[0].ContactID)
+	assert.Equal(t, DomainMigrationFailed, states[1].Status)
+	assert.Contains(t, states[1].Error, "accept push")
+	assert.Equal(t, int64(60), states[1].ContactID)
+	assert.Equal(t, DomainMigrationAccepted, states[2].Status)
+	assert.Equal(t, map[int64]int64{1: 50, 3: 70}, fake.accepted)
+	assert.Equal(t, []string{"a.com initiated", "a.com accepted", "b.com initiated", "b.com failed", "c.com initiated", "c.com accepted"}, progress)
+
+	// a new migration with the same store resumes the failed domain, reusing its push
+	fake.failAccept = nil
+	resumed := NewDomainMigration(client, "1010", client, "2020", "target@example.com")
+	resumed.Store = NewFileDomainMigrationStore(storePath)
+
+	states, err = resumed.Run(context.Background(), []string{"a.com", "b.com", "c.com"})
+
+	assert.NoError(t, err)
+	for _, state := range states {
+		assert.Equal(t, DomainMigrationAccepted, state.Status, state.Domain)
+	}
+	assert.Equal(t, int64(60), fake.accepted[2])
+	assert.Equal(t, []string{"a.com", "b.com", "c.com"}, fake.initiated)
+}
+
+func TestDomainMigration_Cancel(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakePushAccounts{
+		domains:    map[string]Domain{"a.com": {ID: 100, Name: "a.com", RegistrantID: 5}},
+		moved:      map[string]bool{},
+		failAccept: map[int64]bool{1: true},
+		accepted:   map[int64]int64{},
+	}
+	fake.register(t)
+
+	var rejected bool
+	mux.HandleFunc("/v2/2020/pushes/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			rejected = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"Temporary failure"}`)
+	})
+
+	migration := NewDomainMigration(client, "1010", client, "2020", "target@example.com")
+	migration.ContactMap[5] = 50
+
+	states, err := migration.Run(context.Background(), []string{"a.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DomainMigrationFailed, states[0].Status)
+
+	assert.NoError(t, migration.Cancel(context.Background(), "a.com"))
+	assert.True(t, rejected)
+
+	stored, _ := migration.Store.Load(context.Background())
+	assert.Equal(t, DomainMigrationCanceled, stored["a.com"].Status)
+	assert.Error(t, migration.Cancel(context.Background(), "b.com"))
+
+	// A canceled domain is not pushed again.
+	states, err = migration.Run(context.Background(), []string{"a.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, DomainMigrationCanceled, states[0].Status)
+	assert.Equal(t, []string{"a.com"}, fake.initiated)
+
+	// Until it is resumed.
+	assert.NoError(t, migration.Resume(context.Background(), "a.com"))
+	assert.Error(t, migration.Resume(context.Background(), "a.com"))
+	states, err = migration.Run(context.Background(), []string{"a.com"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, DomainMigrationCanceled, states[0].Status)
+}
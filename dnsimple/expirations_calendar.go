@@ -0,0 +1,409 @@
+package dnsimple
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExpirationKind identifies what expires in an ExpirationEvent.
+type ExpirationKind string
+
+// The kinds of expiration exported by ExpirationCalendar.
+const (
+	ExpirationKindDomain       ExpirationKind = "domain"
+	ExpirationKindCertificate  ExpirationKind = "certificate"
+	ExpirationKindWhoisPrivacy ExpirationKind = "whois_privacy"
+)
+
+// ExpirationEvent represents the expiration of a domain, a certificate or a WHOIS privacy.
+type ExpirationEvent struct {
+	Kind ExpirationKind
+	// The ID of the expiring resource.
+	ID     int64
+	Domain string
+	// The common name of the certificate, for certificate expirations.
+	CommonName string
+	ExpiresAt  time.Time
+	AutoRenew  bool
+
+	// The error parsing the expiration, if any. ExpiresAt is then zero,
+	// and the event is left out of the feed.
+	Error string
+}
+
+// UID returns a unique and stable identifier of the event.
+func (e ExpirationEvent) UID() string {
+	return fmt.Sprintf("%v-%v@dnsimple.com", strings.ReplaceAll(string(e.Kind), "_", "-"), e.ID)
+}
+
+// Summary returns a one line description of the event.
+func (e ExpirationEvent) Summary() string {
+	switch e.Kind {
+	case ExpirationKindCertificate:
+		return fmt.Sprintf("Certificate %v expires", e.CommonName)
+	case ExpirationKindWhoisPrivacy:
+		return fmt.Sprintf("WHOIS privacy for %v expires", e.Domain)
+	default:
+		return fmt.Sprintf("Domain %v expires", e.Domain)
+	}
+}
+
+// ExpirationCalendar exports the expirations of the domains, certificates and WHOIS privacies
+// of an account as an iCalendar (RFC 5545) feed.
+//
+// ExpirationCalendar implements http.Handler to serve the feed, so that calendar applications
+// can subscribe to it. The feed served is cached for CacheTTL, so that frequent polling
+// doesn't use up the rate limit of the account.
+type ExpirationCalendar struct {
+	client    *Client
+	accountID string
+
+	// The name of the calendar. Defaults to "DNSimple expirations".
+	Name string
+
+	// The alarms of each event, as durations before the expiration.
+	// Defaults to 30 and 7 days before the expiration.
+	Alarms []time.Duration
+
+	// Skip the expirations of certificates, or WHOIS privacies.
+	SkipCertificates bool
+	SkipWhoisPrivacy bool
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+
+	// How long ServeHTTP serves the same feed before fetching the expirations again.
+	// Defaults to 1 hour, zero disables the cache.
+	CacheTTL time.Duration
+
+	// ErrorLog, if set, logs the errors of ServeHTTP. Defaults to the standard logger.
+	ErrorLog *log.Logger
+
+	mu       sync.Mutex
+	feed     []byte
+	etag     string
+	modified time.Time
+	fetch    *expirationCalendarFetch
+
+	// now can be replaced in tests
+	now func() time.Time
+}
+
+// NewExpirationCalendar returns a calendar of the expirations in the account.
+func NewExpirationCalendar(client *Client, accountID string) *ExpirationCalendar {
+	return &ExpirationCalendar{
+		client:    client,
+		accountID: accountID,
+		Name:      "DNSimple expirations",
+		Alarms:    []time.Duration{30 * 24 * time.Hour, 7 * 24 * time.Hour},
+		CacheTTL:  time.Hour,
+		now:       time.Now,
+	}
+}
+
+// Events fetches the expirations of the account, sorted by expiration.
+//
+// Domains are listed with DomainsService.ListDomains. The certificates of each domain are listed
+// with CertificatesService.ListCertificates, and only issued certificates are included.
+// The WHOIS privacy is fetched with RegistrarService.GetWhoisPrivacy for the domains that have it.
+//
+// An expiration that can't be parsed doesn't stop the others: the event is returned, first,
+// with the error in Error.
+func (c *ExpirationCalendar) Events(ctx context.Context) ([]ExpirationEvent, error) {
+	domains, err := c.client.Domains.listAllDomains(ctx, c.accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := newRateBudget(int(c.RateLimitReserve))
+	var events []ExpirationEvent
+	for _, domain := range domains {
+		if domain.ExpiresAt != "" {
+			event := ExpirationEvent{Kind: ExpirationKindDomain, ID: domain.ID, Domain: domain.Name, AutoRenew: domain.AutoRenew}
+			event.ExpiresAt, event.Error = parseExpiration(time.RFC3339, domain.ExpiresAt)
+			events = append(events, event)
+		}
+
+		if !c.SkipCertificates {
+			certificates, err := c.listAllCertificates(ctx, domain.Name, budget)
+			if err != nil {
+				return nil, err
+			}
+			for _, certificate := range certificates {
+				if certificate.State != "issued" || certificate.ExpiresAt == "" {
+					continue
+				}
+				event := ExpirationEvent{Kind: ExpirationKindCertificate, ID: certificate.ID, Domain: domain.Name, CommonName: certificate.CommonName, AutoRenew: certificate.AutoRenew}
+				event.ExpiresAt, event.Error = parseExpiration(time.RFC3339, certificate.ExpiresAt)
+				events = append(events, event)
+			}
+		}
+
+		if !c.SkipWhoisPrivacy && domain.PrivateWhois {
+			if err := budget.wait(ctx); err != nil {
+				return nil, err
+			}
+			whoisPrivacyResponse, err := c.client.Registrar.GetWhoisPrivacy(ctx, c.accountID, domain.Name)
+			if err != nil {
+				return nil, err
+			}
+			budget.update(&whoisPrivacyResponse.Response)
+			whoisPrivacy := whoisPrivacyResponse.Data
+			if whoisPrivacy.ExpiresOn != "" {
+				event := ExpirationEvent{Kind: ExpirationKindWhoisPrivacy, ID: whoisPrivacy.ID, Domain: domain.Name}
+				event.ExpiresAt, event.Error = parseExpiration("2006-01-02", whoisPrivacy.ExpiresOn)
+				events = append(events, event)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].ExpiresAt.Before(events[j].ExpiresAt) })
+	return events, nil
+}
+
+// parseExpiration parses an expiration, returning the error as a string.
+func parseExpiration(layout string, value string) (time.Time, string) {
+	expiresAt, err := time.Parse(layout, value)
+	if err != nil {
+		return time.Time{}, fmt.Sprintf("invalid expiration %q: %v", value, err)
+	}
+	return expiresAt, ""
+}
+
+func (c *ExpirationCalendar) listAllCertificates(ctx context.Context, domainName string, budget *rateBudget) ([]Certificate, error) {
+	var certificates []Certificate
+	for page := 1; ; page++ {
+		if err := budget.wait(ctx); err != nil {
+			return nil, err
+		}
+		certificatesResponse, err := c.client.Certificates.ListCertificates(ctx, c.accountID, domainName, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+		budget.update(&certificatesResponse.Response)
+
+		certificates = append(certificates, certificatesResponse.Data...)
+		if certificatesResponse.Pagination == nil || page >= certificatesResponse.Pagination.TotalPages {
+			return certificates, nil
+		}
+	}
+}
+
+// Write fetches the expirations of the account and writes them to w as an iCalendar feed.
+func (c *ExpirationCalendar) Write(ctx context.Context, w io.Writer) error {
+	events, err := c.Events(ctx)
+	if err != nil {
+		return err
+	}
+	return c.WriteEvents(w, events)
+}
+
+// WriteEvents writes the events to w as an iCalendar feed.
+//
+// Each event is an all-day event on the expiration date (in UTC), with a display alarm
+// for each of the calendar alarms. The events with an Error are left out.
+func (c *ExpirationCalendar) WriteEvents(w io.Writer, events []ExpirationEvent) error {
+	ics := &icalendarWriter{}
+	ics.line("BEGIN:VCALENDAR")
+	ics.line("VERSION:2.0")
+	ics.line("PRODID:-//DNSimple//dnsimple-go//EN")
+	ics.line("CALSCALE:GREGORIAN")
+	ics.line("METHOD:PUBLISH")
+	ics.line("X-WR-CALNAME:" + icalendarText(c.Name))
+
+	stamp := c.now().UTC().Format("20060102T150405Z")
+	for _, event := range events {
+		if event.Error != "" {
+			continue
+		}
+		day := event.ExpiresAt.UTC()
+		description := fmt.Sprintf("Expires at %v.", event.ExpiresAt.UTC().Format(time.RFC3339))
+		if event.Kind != ExpirationKindWhoisPrivacy {
+			if event.AutoRenew {
+				description += " Auto-renewal is enabled."
+			} else {
+				description += " Auto-renewal is disabled."
+			}
+		}
+
+		ics.line("BEGIN:VEVENT")
+		ics.line("UID:" + event.UID())
+		ics.line("DTSTAMP:" + stamp)
+		ics.line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+		ics.line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		ics.line("SUMMARY:" + icalendarText(event.Summary()))
+		ics.line("DESCRIPTION:" + icalendarText(description))
+		ics.line("CATEGORIES:" + icalendarText(string(event.Kind)))
+		ics.line("TRANSP:TRANSPARENT")
+		for _, alarm := range c.Alarms {
+			ics.line("BEGIN:VALARM")
+			ics.line("ACTION:DISPLAY")
+			ics.line("TRIGGER:" + icalendarDuration(-alarm))
+			ics.line("DESCRIPTION:" + icalendarText(event.Summary()))
+			ics.line("END:VALARM")
+		}
+		ics.line("END:VEVENT")
+	}
+	ics.line("END:VCALENDAR")
+
+	_, err := w.Write(ics.buf.Bytes())
+	return err
+}
+
+// ServeHTTP serves the iCalendar feed.
+//
+// The responses have an ETag and a Last-Modified header, and conditional requests
+// are answered with 304 Not Modified while the feed is unchanged.
+func (c *ExpirationCalendar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	feed, etag, modified, err := c.cachedFeed(r.Context())
+	if err != nil {
+		c.logf("dnsimple: serving the expiration calendar: %v", err)
+		http.Error(w, "Unable to fetch the expirations", http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="expirations.ics"`)
+	w.Header().Set("ETag", etag)
+	http.ServeContent(w, r, "expirations.ics", modified, bytes.NewReader(feed))
+}
+
+// expirationCalendarFetchTimeout bounds a fetch of the feed, that is not bound to any request.
+const expirationCalendarFetchTimeout = 5 * time.Minute
+
+// expirationCalendarFetch is a fetch of the feed shared by the concurrent requests.
+type expirationCalendarFetch struct {
+	done     chan struct{}
+	feed     []byte
+	etag     string
+	modified time.Time
+	err      error
+}
+
+// cachedFeed returns the feed rendered less than CacheTTL ago, or renders it again.
+//
+// Concurrent requests share a single fetch, that is not canceled with the request
+// that started it: a request that is canceled stops waiting without failing the others.
+func (c *ExpirationCalendar) cachedFeed(ctx context.Context) ([]byte, string, time.Time, error) {
+	c.mu.Lock()
+	if c.feed != nil && c.now().Sub(c.modified) < c.CacheTTL {
+		defer c.mu.Unlock()
+		return c.feed, c.etag, c.modified, nil
+	}
+	fetch := c.fetch
+	if fetch == nil {
+		fetch = &expirationCalendarFetch{done: make(chan struct{})}
+		c.fetch = fetch
+		go c.fetchFeed(fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, "", time.Time{}, ctx.Err()
+	case <-fetch.done:
+		return fetch.feed, fetch.etag, fetch.modified, fetch.err
+	}
+}
+
+func (c *ExpirationCalendar) fetchFeed(fetch *expirationCalendarFetch) {
+	ctx, cancel := context.WithTimeout(context.Background(), expirationCalendarFetchTimeout)
+	defer cancel()
+
+	var buf bytes.Buffer
+	fetch.err = c.Write(ctx, &buf)
+	fetch.modified = c.now()
+
+	c.mu.Lock()
+	if fetch.err == nil {
+		fetch.feed = buf.Bytes()
+		fetch.etag = fmt.Sprintf(`"%x"`, sha256.Sum256(fetch.feed))
+		c.feed, c.etag, c.modified = fetch.feed, fetch.etag, fetch.modified
+	}
+	c.fetch = nil
+	c.mu.Unlock()
+	close(fetch.done)
+}
+
+func (c *ExpirationCalendar) logf(format string, args ...interface{}) {
+	if c.ErrorLog != nil {
+		c.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// icalendarWriter writes content lines terminated by CRLF,
+// folding lines longer than 75 octets as required by RFC 5545, section 3.1.
+type icalendarWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icalendarWriter) line(s string) {
+	const limit = 75
+	length := 0
+	for i, r := range s {
+		size := len(string(r))
+		if length+size > limit {
+			w.buf.WriteString("\r\n ")
+			// the leading space counts toward the limit of the continuation line
+			length = 1
+		}
+		w.buf.WriteString(s[i : i+size])
+		length += size
+	}
+	w.buf.WriteString("\r\n")
+}
+
+// icalendarText escapes a TEXT value, see RFC 5545, section 3.3.11.
+func icalendarText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icalendarDuration formats a DURATION value, see RFC 5545, section 3.3.6.
+func icalendarDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if d == 0 {
+		return fmt.Sprintf("%vP%dD", sign, days)
+	}
+
+	value := fmt.Sprintf("%vP", sign)
+	if days > 0 {
+		value += fmt.Sprintf("%dD", days)
+	}
+	value += "T"
+	if hours := d / time.Hour; hours > 0 {
+		value += fmt.Sprintf("%dH", hours)
+		d -= hours * time.Hour
+	}
+	if minutes := d / time.Minute; minutes > 0 {
+		value += fmt.Sprintf("%dM", minutes)
+		d -= minutes * time.Minute
+	}
+	if seconds := d / time.Second; seconds > 0 || strings.HasSuffix(value, "T") {
+		value += fmt.Sprintf("%dS", seconds)
+	}
+	return value
+}
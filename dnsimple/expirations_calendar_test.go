@@ -0,0 +1,263 @@
+package dnsimple
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupExpirationsMock(t *testing.T) {
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"example.com","auto_renew":true,"private_whois":true,"expires_at":"2027-01-10T12:00:00Z"},
+			{"id":2,"name":"hosted.com","expires_at":null}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/example.com/certificates", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":101,"common_name":"www.example.com","state":"issued","auto_renew":false,"expires_at":"2026-12-01T08:00:00Z"},
+			{"id":102,"common_name":"old.example.com","state":"cancelled","expires_at":"2026-11-01T08:00:00Z"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/domains/hosted.com/certificates", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[],"pagination":{"current_page":1,"per_page":30,"total_entries":0,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/registrar/domains/example.com/whois_privacy", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"id":7,"domain_id":1,"expires_on":"2027-01-15","enabled":true}}`)
+	})
+}
+
+func TestExpirationCalendar_Events(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupExpirationsMock(t)
+
+	events, err := NewExpirationCalendar(client, "1010").Events(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, ExpirationKindCertificate, events[0].Kind)
+	assert.Equal(t, "Certificate www.example.com expires", events[0].Summary())
+	assert.Equal(t, ExpirationKindDomain, events[1].Kind)
+	assert.Equal(t, "domain-1@dnsimple.com", events[1].UID())
+	assert.Equal(t, ExpirationKindWhoisPrivacy, events[2].Kind)
+	assert.Equal(t, "whois-privacy-7@dnsimple.com", events[2].UID())
+	assert.Equal(t, time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC), events[2].ExpiresAt)
+}
+
+func TestExpirationCalendar_ServeHTTP(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupExpirationsMock(t)
+
+	calendar := NewExpirationCalendar(client, "1010")
+	calendar.SkipCertificates = true
+	calendar.Alarms = []time.Duration{14 * 24 * time.Hour}
+	calendar.now = func() time.Time { return time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC) }
+
+	recorder := httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("GET", "/expirations.ics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	body := recorder.Body.String()
+	assert.True(t, strings.HasPrefix(body, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(body, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 2, strings.Count(body, "BEGIN:VEVENT"))
+	assert.Contains(t, body, "BEGIN:VEVENT\r\n"+
+		"UID:domain-1@dnsimple.com\r\n"+
+		"DTSTAMP:20261018T093000Z\r\n"+
+		"DTSTART;VALUE=DATE:20270110\r\n"+
+		"DTEND;VALUE=DATE:20270111\r\n"+
+		"SUMMARY:Domain example.com expires\r\n"+
+		"DESCRIPTION:Expires at 2027-01-10T12:00:00Z. Auto-renewal is enabled.\r\n"+
+		"CATEGORIES:domain\r\n"+
+		"TRANSP:TRANSPARENT\r\n"+
+		"BEGIN:VALARM\r\n"+
+		"ACTION:DISPLAY\r\n"+
+		"TRIGGER:-P14D\r\n")
+	assert.Contains(t, body, "SUMMARY:WHOIS privacy for example.com expires\r\n")
+
+	recorder = httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("POST", "/expirations.ics", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestExpirationCalendar_ServeHTTP_Cache(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	var listed int
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		listed++
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com","expires_at":"2027-01-10T12:00:00Z"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	calendar := NewExpirationCalendar(client, "1010")
+	calendar.SkipCertificates = true
+	calendar.CacheTTL = 10 * time.Minute
+	calendar.now = func() time.Time { return now }
+
+	recorder := httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("GET", "/expirations.ics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Sun, 18 Oct 2026 09:30:00 GMT", recorder.Header().Get("Last-Modified"))
+	etag := recorder.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	body := recorder.Body.String()
+
+	now = now.Add(5 * time.Minute)
+	recorder = httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("GET", "/expirations.ics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, body, recorder.Body.String())
+	assert.Equal(t, 1, listed)
+
+	request := httptest.NewRequest("GET", "/expirations.ics", nil)
+	request.Header.Set("If-None-Match", etag)
+	recorder = httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+	assert.Equal(t, 1, listed)
+
+	now = now.Add(5 * time.Minute)
+	recorder = httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("GET", "/expirations.ics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "Sun, 18 Oct 2026 09:40:00 GMT", recorder.Header().Get("Last-Modified"))
+	assert.Equal(t, 2, listed)
+}
+
+func TestExpirationCalendar_InvalidExpiration(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"example.com","expires_at":"2027-01-10T12:00:00Z"},
+			{"id":2,"name":"broken.com","expires_at":"2027-01-10"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+
+	calendar := NewExpirationCalendar(client, "1010")
+	calendar.SkipCertificates = true
+	events, err := calendar.Events(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "broken.com", events[0].Domain)
+	assert.Contains(t, events[0].Error, `invalid expiration "2027-01-10"`)
+	assert.Empty(t, events[1].Error)
+
+	var buf bytes.Buffer
+	assert.NoError(t, calendar.WriteEvents(&buf, events))
+	assert.Equal(t, 1, strings.Count(buf.String(), "BEGIN:VEVENT"))
+	assert.NotContains(t, buf.String(), "broken.com")
+}
+
+func TestExpirationCalendar_ServeHTTP_SharedFetch(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	var mu sync.Mutex
+	listed := 0
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		listed++
+		mu.Unlock()
+		close(started)
+		<-release
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com","expires_at":"2027-01-10T12:00:00Z"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+
+	calendar := NewExpirationCalendar(client, "1010")
+	calendar.SkipCertificates = true
+	calendar.ErrorLog = log.New(io.Discard, "", 0)
+
+	// The request that starts the fetch is canceled while the fetch is in progress.
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		calendar.ServeHTTP(canceled, httptest.NewRequest("GET", "/expirations.ics", nil).WithContext(ctx))
+	}()
+	<-started
+
+	waiting := httptest.NewRecorder()
+	served := make(chan struct{})
+	go func() {
+		defer close(served)
+		calendar.ServeHTTP(waiting, httptest.NewRequest("GET", "/expirations.ics", nil))
+	}()
+
+	cancel()
+	<-done
+	assert.Equal(t, http.StatusBadGateway, canceled.Code)
+
+	close(release)
+	<-served
+	assert.Equal(t, http.StatusOK, waiting.Code)
+	assert.Contains(t, waiting.Body.String(), "SUMMARY:Domain example.com expires")
+	assert.Equal(t, 1, listed)
+}
+
+func TestExpirationCalendar_ServeHTTP_Error(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Authentication failed"}`)
+	})
+
+	var logs bytes.Buffer
+	calendar := NewExpirationCalendar(client, "1010")
+	calendar.ErrorLog = log.New(&logs, "", 0)
+
+	recorder := httptest.NewRecorder()
+	calendar.ServeHTTP(recorder, httptest.NewRequest("GET", "/expirations.ics", nil))
+
+	assert.Equal(t, http.StatusBadGateway, recorder.Code)
+	assert.Equal(t, "Unable to fetch the expirations\n", recorder.Body.String())
+	assert.Contains(t, logs.String(), "Authentication failed")
+}
+
+func TestICalendarWriter_Folding(t *testing.T) {
+	ics := &icalendarWriter{}
+	ics.line("DESCRIPTION:" + strings.Repeat("x", 100))
+
+	lines := strings.Split(strings.TrimSuffix(ics.buf.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 2)
+	assert.Len(t, lines[0], 75)
+	assert.Equal(t, " "+strings.Repeat("x", 37), lines[1])
+}
+
+func TestICalendarText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne`, icalendarText("a, b; c\\d\ne"))
+}
+
+func TestICalendarDuration(t *testing.T) {
+	assert.Equal(t, "-P30D", icalendarDuration(-30*24*time.Hour))
+	assert.Equal(t, "-P1DT2H", icalendarDuration(-26*time.Hour))
+	assert.Equal(t, "PT1H30M", icalendarDuration(90*time.Minute))
+	assert.Equal(t, "P0D", icalendarDuration(0))
+	assert.Equal(t, "PT0S", icalendarDuration(time.Millisecond))
+}
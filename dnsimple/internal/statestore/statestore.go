@@ -0,0 +1,159 @@
+// Package statestore keeps the states of long running operations, such as migrations,
+// transfers or key rollovers, keyed by the resource they apply to.
+package statestore
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Key is the type of the keys of the states.
+type Key interface {
+	~int64 | ~string
+}
+
+// Memory keeps the states in memory. The states are copied in and out,
+// so that callers can't modify the stored states.
+type Memory[K Key, V any] struct {
+	key func(*V) K
+
+	mu     sync.Mutex
+	states map[K]*V
+}
+
+// NewMemory returns an empty memory store of the states keyed by key.
+func NewMemory[K Key, V any](key func(*V) K) *Memory[K, V] {
+	return &Memory[K, V]{key: key, states: map[K]*V{}}
+}
+
+// Load returns a copy of every state.
+func (s *Memory[K, V]) Load() (map[K]*V, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make(map[K]*V, len(s.states))
+	for key, state := range s.states {
+		copied := *state
+		states[key] = &copied
+	}
+	return states, nil
+}
+
+// Save stores a copy of the state, replacing the state with the same key.
+func (s *Memory[K, V]) Save(state *V) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *state
+	s.states[s.key(state)] = &copied
+	return nil
+}
+
+// File keeps the states in a JSON file, as a list sorted by key.
+//
+// The file is rewritten atomically on every save. A File is safe for concurrent use
+// within a process, but the file must not be shared by several processes.
+type File[K Key, V any] struct {
+	path string
+	key  func(*V) K
+
+	mu sync.Mutex
+}
+
+// NewFile returns a store of the states keyed by key, backed by the file at path.
+// The file is created on the first save.
+func NewFile[K Key, V any](path string, key func(*V) K) *File[K, V] {
+	return &File[K, V]{path: path, key: key}
+}
+
+// Load returns every state of the file.
+func (s *File[K, V]) Load() (map[K]*V, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.read()
+}
+
+// List returns every state of the file, sorted by key.
+func (s *File[K, V]) List() ([]*V, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return s.sorted(states), nil
+}
+
+// Save stores a copy of the state, replacing the state with the same key.
+func (s *File[K, V]) Save(state *V) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	copied := *state
+	states[s.key(state)] = &copied
+
+	data, err := json.MarshalIndent(s.sorted(states), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+func (s *File[K, V]) read() (map[K]*V, error) {
+	states := map[K]*V{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*V
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	for _, state := range list {
+		states[s.key(state)] = state
+	}
+	return states, nil
+}
+
+func (s *File[K, V]) sorted(states map[K]*V) []*V {
+	list := make([]*V, 0, len(states))
+	for _, state := range states {
+		list = append(list, state)
+	}
+	sort.Slice(list, func(i, j int) bool { return s.key(list[i]) < s.key(list[j]) })
+	return list
+}
+
+// writeFileAtomic replaces the file at path with data, writing to a temporary file
+// in the same directory first so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,66 @@
+package statestore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type state struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+}
+
+func stateKey(s *state) int64 {
+	return s.ID
+}
+
+func TestMemory(t *testing.T) {
+	store := NewMemory(stateKey)
+
+	saved := &state{ID: 1, Status: "pending"}
+	assert.NoError(t, store.Save(saved))
+	saved.Status = "modified"
+
+	states, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[int64]*state{1: {ID: 1, Status: "pending"}}, states)
+
+	states[1].Status = "modified"
+	states, _ = store.Load()
+	assert.Equal(t, "pending", states[1].Status)
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "states.json")
+	store := NewFile(path, stateKey)
+
+	states, err := store.Load()
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+
+	assert.NoError(t, store.Save(&state{ID: 2, Status: "pending"}))
+	assert.NoError(t, store.Save(&state{ID: 1, Status: "pending"}))
+	assert.NoError(t, store.Save(&state{ID: 2, Status: "done"}))
+
+	list, err := NewFile(path, stateKey).List()
+	assert.NoError(t, err)
+	assert.Equal(t, []*state{{ID: 1, Status: "pending"}, {ID: 2, Status: "done"}}, list)
+
+	// no temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, err := NewFile(path, stateKey).Load()
+	assert.Error(t, err)
+	assert.Error(t, NewFile(path, stateKey).Save(&state{ID: 1}))
+}
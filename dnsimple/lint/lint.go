@@ -0,0 +1,304 @@
+// Package lint provides checks for common DNS misconfigurations
+// in the records of a DNSimple zone.
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// Severity represents how serious a finding is.
+type Severity int
+
+const (
+	// SeverityInfo is used for findings that are worth knowing but rarely a problem.
+	SeverityInfo Severity = iota
+	// SeverityWarning is used for findings that are likely unintended.
+	SeverityWarning
+	// SeverityError is used for findings that break resolution or violate the DNS specifications.
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// String returns the lowercase name of the severity.
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range severityNames {
+		if name == string(text) {
+			*s = severity
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown severity %q", text)
+}
+
+// Rule names, used in Finding.Rule and Linter.Disabled.
+const (
+	RuleCNAMECoexistence = "cname_coexistence"
+	RuleDanglingTarget   = "dangling_target"
+	RuleDuplicateRecord  = "duplicate_record"
+	RuleInconsistentTTL  = "inconsistent_ttl"
+	RuleTargetIsCNAME    = "target_is_cname"
+	RuleMissingSPF       = "missing_spf"
+	RuleMissingDMARC     = "missing_dmarc"
+	RuleWildcardShadow   = "wildcard_shadowing"
+)
+
+// Finding represents a single problem found in a zone.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// The record name the finding refers to, relative to the zone apex.
+	Name string `json:"name"`
+	// The record type the finding refers to, if any.
+	Type    string `json:"type,omitempty"`
+	Message string `json:"message"`
+	// The IDs of the records involved, if known.
+	RecordIDs []int64 `json:"record_ids,omitempty"`
+}
+
+// Report represents the result of linting a zone.
+type Report struct {
+	Zone     string    `json:"zone"`
+	Findings []Finding `json:"findings"`
+}
+
+// MaxSeverity returns the highest severity among the findings, and false if there are no findings.
+func (r *Report) MaxSeverity() (Severity, bool) {
+	if len(r.Findings) == 0 {
+		return SeverityInfo, false
+	}
+	max := r.Findings[0].Severity
+	for _, finding := range r.Findings[1:] {
+		if finding.Severity > max {
+			max = finding.Severity
+		}
+	}
+	return max, true
+}
+
+// WriteJSON writes the report to w as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Linter runs the checks over the records of a zone.
+// The zero value runs every rule.
+type Linter struct {
+	// Disabled lists the rules that should not be run.
+	Disabled []string
+}
+
+// Lint checks the records of a zone with the default Linter.
+func Lint(zoneName string, records []dnsimple.ZoneRecord) *Report {
+	return (&Linter{}).Lint(zoneName, records)
+}
+
+// LintZone fetches every record of a zone and checks them with the default Linter.
+func LintZone(ctx context.Context, client *dnsimple.Client, accountID string, zoneName string) (*Report, error) {
+	return (&Linter{}).LintZone(ctx, client, accountID, zoneName)
+}
+
+// LintZone fetches every record of a zone and checks them.
+func (l *Linter) LintZone(ctx context.Context, client *dnsimple.Client, accountID string, zoneName string) (*Report, error) {
+	var records []dnsimple.ZoneRecord
+	options := &dnsimple.ZoneRecordListOptions{}
+	for page := 1; ; page++ {
+		options.Page = dnsimple.Int(page)
+
+		recordsResponse, err := client.Zones.ListRecords(ctx, accountID, zoneName, options)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, recordsResponse.Data...)
+		if recordsResponse.Pagination == nil || page >= recordsResponse.Pagination.TotalPages {
+			break
+		}
+	}
+
+	return l.Lint(zoneName, records), nil
+}
+
+// Lint checks the records of a zone. Record names are expected to be relative to zoneName,
+// with an empty name for the apex, as returned by ZonesService.ListRecords.
+func (l *Linter) Lint(zoneName string, records []dnsimple.ZoneRecord) *Report {
+	z := newZone(zoneName, records)
+
+	checks := []struct {
+		rule  string
+		check func(*zone) []Finding
+	}{
+		{RuleCNAMECoexistence, checkCNAMECoexistence},
+		{RuleDanglingTarget, checkDanglingTargets},
+		{RuleDuplicateRecord, checkDuplicates},
+		{RuleInconsistentTTL, checkTTLs},
+		{RuleTargetIsCNAME, checkTargetsAreCNAME},
+		{RuleMissingSPF, checkSPF},
+		{RuleMissingDMARC, checkDMARC},
+		{RuleWildcardShadow, checkWildcardShadowing},
+	}
+
+	report := &Report{Zone: z.name, Findings: []Finding{}}
+	for _, c := range checks {
+		if l.disabled(c.rule) {
+			continue
+		}
+		report.Findings = append(report.Findings, c.check(z)...)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity > b.Severity
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Rule < b.Rule
+	})
+	return report
+}
+
+func (l *Linter) disabled(rule string) bool {
+	for _, r := range l.Disabled {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// zone indexes the records of a zone by name and type.
+type zone struct {
+	name    string
+	records []dnsimple.ZoneRecord
+	// records indexed by lowercase relative name, then by type
+	byName map[string]map[string][]dnsimple.ZoneRecord
+	// names sorted alphabetically, for deterministic output
+	names []string
+}
+
+func newZone(zoneName string, records []dnsimple.ZoneRecord) *zone {
+	z := &zone{
+		name:    normalizeName(zoneName),
+		records: records,
+		byName:  map[string]map[string][]dnsimple.ZoneRecord{},
+	}
+	for _, record := range records {
+		name := normalizeName(record.Name)
+		if z.byName[name] == nil {
+			z.byName[name] = map[string][]dnsimple.ZoneRecord{}
+			z.names = append(z.names, name)
+		}
+		z.byName[name][record.Type] = append(z.byName[name][record.Type], record)
+	}
+	sort.Strings(z.names)
+	return z
+}
+
+// relative returns a fully qualified target relative to the zone apex,
+// and false if the target is outside the zone.
+func (z *zone) relative(target string) (string, bool) {
+	target = normalizeName(target)
+	if target == z.name {
+		return "", true
+	}
+	if strings.HasSuffix(target, "."+z.name) {
+		return strings.TrimSuffix(target, "."+z.name), true
+	}
+	return "", false
+}
+
+// exists reports whether a name has records, either directly or through a wildcard,
+// or is an empty non-terminal.
+func (z *zone) exists(name string) bool {
+	if _, ok := z.byName[name]; ok || name == "" {
+		return true
+	}
+	for _, other := range z.names {
+		if strings.HasSuffix(other, "."+name) {
+			return true
+		}
+	}
+	return z.wildcardFor(name) != ""
+}
+
+// wildcardFor returns the wildcard name that synthesizes records for name, if any.
+func (z *zone) wildcardFor(name string) string {
+	for parent := parentName(name); ; parent = parentName(parent) {
+		wildcard := "*"
+		if parent != "" {
+			wildcard += "." + parent
+		}
+		if _, ok := z.byName[wildcard]; ok && wildcard != name {
+			return wildcard
+		}
+		if parent == "" {
+			return ""
+		}
+	}
+}
+
+func (z *zone) display(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// parentName returns the name with the leftmost label removed.
+func parentName(name string) string {
+	if i := strings.Index(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+func recordIDs(records []dnsimple.ZoneRecord) []int64 {
+	var ids []int64
+	for _, record := range records {
+		if record.ID != 0 {
+			ids = append(ids, record.ID)
+		}
+	}
+	return ids
+}
+
+func sortedTypes(types map[string][]dnsimple.ZoneRecord) []string {
+	keys := make([]string, 0, len(types))
+	for t := range types {
+		keys = append(keys, t)
+	}
+	sort.Strings(keys)
+	return keys
+}
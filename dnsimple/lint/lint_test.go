@@ -0,0 +1,221 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+	"github.com/stretchr/testify/assert"
+)
+
+func findingsByRule(report *Report, rule string) []Finding {
+	var findings []Finding
+	for _, finding := range report.Findings {
+		if finding.Rule == rule {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func TestLint_CleanZone(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "", Type: "SOA", Content: "ns1.dnsimple.com admin.dnsimple.com 1 86400 7200 604800 300", TTL: 3600},
+		{ID: 2, Name: "", Type: "NS", Content: "ns1.dnsimple.com", TTL: 3600},
+		{ID: 3, Name: "", Type: "NS", Content: "ns2.dnsimple.com", TTL: 3600},
+		{ID: 4, Name: "", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: 5, Name: "", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 3600},
+		{ID: 6, Name: "", Type: "TXT", Content: "v=spf1 mx -all", TTL: 3600},
+		{ID: 7, Name: "_dmarc", Type: "TXT", Content: "v=DMARC1; p=reject", TTL: 3600},
+		{ID: 8, Name: "mail", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: 9, Name: "www", Type: "CNAME", Content: "example.com", TTL: 3600},
+	}
+
+	report := Lint("example.com", records)
+
+	assert.Equal(t, "example.com", report.Zone)
+	assert.Empty(t, report.Findings)
+	_, ok := report.MaxSeverity()
+	assert.False(t, ok)
+}
+
+func TestLint_CNAMECoexistence(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "CNAME", Content: "example.com"},
+		{ID: 2, Name: "www", Type: "TXT", Content: "hello"},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleCNAMECoexistence)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Equal(t, "www", findings[0].Name)
+	assert.Equal(t, []int64{1, 2}, findings[0].RecordIDs)
+}
+
+func TestLint_DanglingTarget(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "CNAME", Content: "missing.example.com"},
+		{ID: 2, Name: "", Type: "ALIAS", Content: "app.example.com"},
+		{ID: 3, Name: "api", Type: "CNAME", Content: "service.example.net"},
+		{ID: 4, Name: "docs", Type: "CNAME", Content: "b.example.com"},
+		{ID: 5, Name: "a.b", Type: "A", Content: "192.0.2.1"},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleDanglingTarget)
+
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "@", findings[0].Name)
+	assert.Equal(t, "ALIAS", findings[0].Type)
+	assert.Equal(t, "www", findings[1].Name)
+}
+
+func TestLint_DanglingTarget_Wildcard(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "CNAME", Content: "anything.example.com"},
+		{ID: 2, Name: "*", Type: "A", Content: "192.0.2.1"},
+	}
+
+	assert.Empty(t, findingsByRule(Lint("example.com", records), RuleDanglingTarget))
+}
+
+func TestLint_DuplicateRecord(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "A", Content: "192.0.2.1", Regions: []string{"global"}},
+		{ID: 2, Name: "www", Type: "A", Content: "192.0.2.1", Regions: []string{"global"}},
+		{ID: 3, Name: "www", Type: "A", Content: "192.0.2.1", Regions: []string{"IAD"}},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleDuplicateRecord)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, []int64{1, 2}, findings[0].RecordIDs)
+}
+
+func TestLint_InconsistentTTL(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: 2, Name: "www", Type: "A", Content: "192.0.2.2", TTL: 60},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleInconsistentTTL)
+
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "A records at the same name have different TTLs: 3600, 60", findings[0].Message)
+}
+
+func TestLint_TargetIsCNAME(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "", Type: "MX", Content: "mail.example.com"},
+		{ID: 2, Name: "sub", Type: "NS", Content: "ns.example.com"},
+		{ID: 3, Name: "mail", Type: "CNAME", Content: "mx.example.net"},
+		{ID: 4, Name: "ns", Type: "CNAME", Content: "ns.example.net"},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleTargetIsCNAME)
+
+	assert.Len(t, findings, 2)
+	assert.Equal(t, "MX", findings[0].Type)
+	assert.Equal(t, "NS", findings[1].Type)
+}
+
+func TestLint_MissingSPFAndDMARC(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "", Type: "MX", Content: "mx.example.net"},
+		{ID: 2, Name: "", Type: "TXT", Content: "google-site-verification=abc"},
+		{ID: 3, Name: "lists", Type: "MX", Content: "mx.example.net"},
+		{ID: 4, Name: "lists", Type: "TXT", Content: `"v=spf1 include:example.net -all"`},
+	}
+
+	report := Lint("example.com", records)
+
+	spf := findingsByRule(report, RuleMissingSPF)
+	assert.Len(t, spf, 1)
+	assert.Equal(t, "@", spf[0].Name)
+
+	dmarc := findingsByRule(report, RuleMissingDMARC)
+	assert.Len(t, dmarc, 2)
+
+	records = append(records, dnsimple.ZoneRecord{ID: 5, Name: "_dmarc", Type: "TXT", Content: "v=DMARC1; p=none"})
+	assert.Empty(t, findingsByRule(Lint("example.com", records), RuleMissingDMARC))
+}
+
+func TestLint_WildcardShadowing(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "*", Type: "A", Content: "192.0.2.1"},
+		{ID: 2, Name: "*", Type: "AAAA", Content: "2001:db8::1"},
+		{ID: 3, Name: "foo", Type: "TXT", Content: "hello"},
+		{ID: 4, Name: "bar", Type: "A", Content: "192.0.2.2"},
+		{ID: 5, Name: "baz", Type: "A", Content: "192.0.2.3"},
+		{ID: 6, Name: "baz", Type: "AAAA", Content: "2001:db8::3"},
+		{ID: 7, Name: "deep.ent", Type: "A", Content: "192.0.2.4"},
+	}
+
+	findings := findingsByRule(Lint("example.com", records), RuleWildcardShadow)
+
+	assert.Len(t, findings, 3)
+	assert.Equal(t, "bar", findings[0].Name)
+	assert.Equal(t, "name shadows wildcard *, so AAAA queries return no data", findings[0].Message)
+	assert.Equal(t, "ent", findings[1].Name)
+	assert.Equal(t, "foo", findings[2].Name)
+}
+
+func TestLinter_Disabled(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "CNAME", Content: "example.com"},
+		{ID: 2, Name: "www", Type: "TXT", Content: "hello"},
+	}
+
+	linter := &Linter{Disabled: []string{RuleCNAMECoexistence}}
+
+	assert.Empty(t, linter.Lint("example.com", records).Findings)
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	records := []dnsimple.ZoneRecord{
+		{ID: 1, Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: 2, Name: "www", Type: "A", Content: "192.0.2.2", TTL: 60},
+		{ID: 3, Name: "www", Type: "CNAME", Content: "example.com", TTL: 60},
+	}
+	report := Lint("example.com.", records)
+
+	severity, ok := report.MaxSeverity()
+	assert.True(t, ok)
+	assert.Equal(t, SeverityError, severity)
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteJSON(&buf))
+
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, &decoded)
+	assert.Contains(t, buf.String(), `"severity": "error"`)
+}
+
+func TestLintZone(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":1,"name":"www","type":"CNAME","content":"example.com"}],"pagination":{"current_page":1,"per_page":1,"total_entries":2,"total_pages":2}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":2,"name":"www","type":"A","content":"192.0.2.1"}],"pagination":{"current_page":2,"per_page":1,"total_entries":2,"total_pages":2}}`)
+		}
+	})
+
+	client := dnsimple.NewClient(http.DefaultClient)
+	client.BaseURL = server.URL
+
+	report, err := LintZone(context.Background(), client, "1010", "example.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, findingsByRule(report, RuleCNAMECoexistence), 1)
+}
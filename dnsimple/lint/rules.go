@@ -0,0 +1,297 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple"
+)
+
+// checkCNAMECoexistence reports names where a CNAME record coexists with records of other types.
+// A CNAME must be the only record at its name (RFC 1034, section 3.6.2).
+func checkCNAMECoexistence(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		types := z.byName[name]
+		cnames, ok := types["CNAME"]
+		if !ok {
+			continue
+		}
+
+		var others []string
+		involved := recordIDs(cnames)
+		for _, t := range sortedTypes(types) {
+			if t == "CNAME" {
+				continue
+			}
+			others = append(others, t)
+			involved = append(involved, recordIDs(types[t])...)
+		}
+		if len(cnames) > 1 {
+			others = append(others, "CNAME")
+		}
+		if len(others) == 0 {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Rule:      RuleCNAMECoexistence,
+			Severity:  SeverityError,
+			Name:      z.display(name),
+			Type:      "CNAME",
+			Message:   fmt.Sprintf("CNAME record coexists with %v records at the same name", strings.Join(others, ", ")),
+			RecordIDs: involved,
+		})
+	}
+	return findings
+}
+
+// checkDanglingTargets reports CNAME and ALIAS records pointing to names
+// within the same zone that have no records.
+func checkDanglingTargets(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		for _, t := range []string{"CNAME", "ALIAS"} {
+			for _, record := range z.byName[name][t] {
+				target, inZone := z.relative(record.Content)
+				if !inZone || z.exists(target) {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:      RuleDanglingTarget,
+					Severity:  SeverityWarning,
+					Name:      z.display(name),
+					Type:      t,
+					Message:   fmt.Sprintf("%v record points to %v, which does not exist in the zone", t, normalizeName(record.Content)),
+					RecordIDs: recordIDs([]dnsimple.ZoneRecord{record}),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkDuplicates reports records with the same name, type, content, priority and regions.
+func checkDuplicates(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		types := z.byName[name]
+		for _, t := range sortedTypes(types) {
+			seen := map[string][]dnsimple.ZoneRecord{}
+			var keys []string
+			for _, record := range types[t] {
+				regions := append([]string(nil), record.Regions...)
+				sort.Strings(regions)
+				key := fmt.Sprintf("%v|%v|%v", normalizeContent(t, record.Content), record.Priority, strings.Join(regions, ","))
+				if _, ok := seen[key]; !ok {
+					keys = append(keys, key)
+				}
+				seen[key] = append(seen[key], record)
+			}
+			for _, key := range keys {
+				duplicates := seen[key]
+				if len(duplicates) < 2 {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:      RuleDuplicateRecord,
+					Severity:  SeverityWarning,
+					Name:      z.display(name),
+					Type:      t,
+					Message:   fmt.Sprintf("%d identical %v records with content %q", len(duplicates), t, duplicates[0].Content),
+					RecordIDs: recordIDs(duplicates),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkTTLs reports RRsets whose records don't share the same TTL (RFC 2181, section 5.2).
+func checkTTLs(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		types := z.byName[name]
+		for _, t := range sortedTypes(types) {
+			ttls := map[int]bool{}
+			var values []string
+			for _, record := range types[t] {
+				if !ttls[record.TTL] {
+					ttls[record.TTL] = true
+					values = append(values, fmt.Sprintf("%d", record.TTL))
+				}
+			}
+			if len(ttls) < 2 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Rule:      RuleInconsistentTTL,
+				Severity:  SeverityWarning,
+				Name:      z.display(name),
+				Type:      t,
+				Message:   fmt.Sprintf("%v records at the same name have different TTLs: %v", t, strings.Join(values, ", ")),
+				RecordIDs: recordIDs(types[t]),
+			})
+		}
+	}
+	return findings
+}
+
+// checkTargetsAreCNAME reports MX and NS records pointing to names that are CNAMEs
+// (RFC 2181, section 10.3).
+func checkTargetsAreCNAME(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		for _, t := range []string{"MX", "NS"} {
+			for _, record := range z.byName[name][t] {
+				target, inZone := z.relative(record.Content)
+				if !inZone {
+					continue
+				}
+				if _, ok := z.byName[target]["CNAME"]; !ok {
+					continue
+				}
+				findings = append(findings, Finding{
+					Rule:      RuleTargetIsCNAME,
+					Severity:  SeverityError,
+					Name:      z.display(name),
+					Type:      t,
+					Message:   fmt.Sprintf("%v record points to %v, which is a CNAME", t, normalizeName(record.Content)),
+					RecordIDs: recordIDs([]dnsimple.ZoneRecord{record}),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkSPF reports names that receive email but don't publish an SPF policy.
+func checkSPF(z *zone) []Finding {
+	var findings []Finding
+	for _, name := range z.names {
+		types := z.byName[name]
+		if len(types["MX"]) == 0 || hasTXTPrefix(append(types["TXT"], types["SPF"]...), "v=spf1") {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:     RuleMissingSPF,
+			Severity: SeverityWarning,
+			Name:     z.display(name),
+			Type:     "TXT",
+			Message:  "MX records exist but no SPF policy (v=spf1) is published",
+		})
+	}
+	return findings
+}
+
+// checkDMARC reports names that receive email without a DMARC policy,
+// either at the name itself or at the zone apex.
+func checkDMARC(z *zone) []Finding {
+	apexPolicy := hasTXTPrefix(z.byName["_dmarc"]["TXT"], "v=DMARC1")
+
+	var findings []Finding
+	for _, name := range z.names {
+		if len(z.byName[name]["MX"]) == 0 || apexPolicy {
+			continue
+		}
+		if hasTXTPrefix(z.byName["_dmarc."+name]["TXT"], "v=DMARC1") {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:     RuleMissingDMARC,
+			Severity: SeverityWarning,
+			Name:     z.display(name),
+			Type:     "TXT",
+			Message:  "MX records exist but no DMARC policy (v=DMARC1) is published at _dmarc",
+		})
+	}
+	return findings
+}
+
+// checkWildcardShadowing reports names that prevent a wildcard from matching,
+// while lacking some of the record types the wildcard provides.
+// Queries for those types at the name return no data instead of the wildcard records.
+func checkWildcardShadowing(z *zone) []Finding {
+	var findings []Finding
+	for _, wildcard := range z.names {
+		if !strings.HasPrefix(wildcard, "*") || (wildcard != "*" && !strings.HasPrefix(wildcard, "*.")) {
+			continue
+		}
+		parent := parentName(wildcard)
+		wildcardTypes := sortedTypes(z.byName[wildcard])
+
+		for _, child := range z.children(parent) {
+			if child == wildcard {
+				continue
+			}
+			var missing []string
+			for _, t := range wildcardTypes {
+				if len(z.byName[child][t]) == 0 && len(z.byName[child]["CNAME"]) == 0 {
+					missing = append(missing, t)
+				}
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Rule:     RuleWildcardShadow,
+				Severity: SeverityInfo,
+				Name:     z.display(child),
+				Message:  fmt.Sprintf("name shadows wildcard %v, so %v queries return no data", wildcard, strings.Join(missing, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+// children returns the names immediately below parent that exist in the zone,
+// including empty non-terminals.
+func (z *zone) children(parent string) []string {
+	seen := map[string]bool{}
+	var children []string
+	for _, name := range z.names {
+		var rest string
+		switch {
+		case parent == "":
+			rest = name
+		case strings.HasSuffix(name, "."+parent):
+			rest = strings.TrimSuffix(name, "."+parent)
+		default:
+			continue
+		}
+		if rest == "" {
+			continue
+		}
+
+		labels := strings.Split(rest, ".")
+		child := labels[len(labels)-1]
+		if parent != "" {
+			child += "." + parent
+		}
+		if !seen[child] {
+			seen[child] = true
+			children = append(children, child)
+		}
+	}
+	sort.Strings(children)
+	return children
+}
+
+func hasTXTPrefix(records []dnsimple.ZoneRecord, prefix string) bool {
+	for _, record := range records {
+		content := strings.Trim(record.Content, `"`)
+		if strings.HasPrefix(strings.ToLower(content), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeContent(recordType string, content string) string {
+	switch recordType {
+	case "CNAME", "ALIAS", "MX", "NS", "PTR":
+		return normalizeName(content)
+	}
+	return content
+}
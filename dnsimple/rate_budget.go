@@ -0,0 +1,99 @@
+package dnsimple
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the number of concurrent operations of the methods that work
+// on many domains or zones at once, such as ZonesService.SearchRecords,
+// when the Concurrency option is not set.
+const DefaultConcurrency = 4
+
+// RateLimitReserve is the number of requests to keep available in the current rate limit window,
+// for the methods that send many requests at once.
+//
+// When the remaining rate limit falls to the reserve, the method waits for the window to reset
+// before sending more requests, leaving the reserve to the other clients of the account.
+type RateLimitReserve int
+
+// concurrency returns n, or DefaultConcurrency when n is not positive.
+func concurrency(n int) int {
+	if n < 1 {
+		return DefaultConcurrency
+	}
+	return n
+}
+
+// rateBudget keeps track of the API rate limit across concurrent requests,
+// and holds requests back when the remaining budget falls to the reserve.
+type rateBudget struct {
+	reserve int
+
+	mu        sync.Mutex
+	known     bool
+	remaining int
+	reset     time.Time
+
+	// now and sleep can be replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRateBudget(reserve int) *rateBudget {
+	return &rateBudget{reserve: reserve, now: time.Now, sleep: sleepContext}
+}
+
+// wait blocks until a request can be sent without eating into the reserve.
+//
+// When the budget is exhausted every caller sleeps until the reset time of the window,
+// the budget is assumed to be renewed only once the reset time is reached.
+func (b *rateBudget) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		var delay time.Duration
+		if b.known && b.remaining <= b.reserve {
+			delay = b.reset.Sub(b.now())
+			if delay <= 0 {
+				b.known = false
+			}
+		}
+		if b.known && delay <= 0 {
+			b.remaining--
+		}
+		b.mu.Unlock()
+
+		if delay <= 0 {
+			return ctx.Err()
+		}
+		if err := b.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// update records the rate limit headers of a response.
+func (b *rateBudget) update(resp *Response) {
+	if resp == nil || resp.HTTPResponse == nil || resp.HTTPResponse.Header.Get("X-RateLimit-Remaining") == "" {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.known = true
+	b.remaining = resp.RateLimitRemaining()
+	b.reset = resp.RateLimitReset()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,121 @@
+package dnsimple
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitedResponse(remaining int, reset time.Time) *Response {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "2400")
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return &Response{HTTPResponse: &http.Response{Header: header}}
+}
+
+func TestRateBudget_Wait(t *testing.T) {
+	now := time.Unix(1475662530, 0)
+	var slept []time.Duration
+
+	budget := newRateBudget(1)
+	budget.now = func() time.Time { return now }
+	budget.sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		now = now.Add(d)
+		return nil
+	}
+
+	// Unknown budget: requests are not held back.
+	assert.NoError(t, budget.wait(context.Background()))
+	assert.Empty(t, slept)
+
+	budget.update(rateLimitedResponse(2, now.Add(30*time.Second)))
+
+	assert.NoError(t, budget.wait(context.Background()))
+	assert.Empty(t, slept)
+
+	assert.NoError(t, budget.wait(context.Background()))
+	assert.Equal(t, []time.Duration{30 * time.Second}, slept)
+
+	// Once the window is reset, requests are not held back until the next response.
+	assert.NoError(t, budget.wait(context.Background()))
+	assert.Equal(t, []time.Duration{30 * time.Second}, slept)
+}
+
+func TestRateBudget_UpdateWithoutHeaders(t *testing.T) {
+	budget := newRateBudget(10)
+	budget.update(&Response{HTTPResponse: &http.Response{Header: http.Header{}}})
+	budget.update(nil)
+
+	assert.False(t, budget.known)
+}
+
+func TestRateBudget_WaitCanceled(t *testing.T) {
+	budget := newRateBudget(5)
+	budget.update(rateLimitedResponse(0, time.Now().Add(time.Hour)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, budget.wait(ctx), context.Canceled)
+}
+
+func TestRateBudget_WaitConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Unix(1475662530, 0)
+	reset := now.Add(30 * time.Second)
+	var slept []time.Duration
+	release := make(chan struct{})
+
+	budget := newRateBudget(0)
+	budget.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	budget.sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		slept = append(slept, d)
+		mu.Unlock()
+		<-release
+		return nil
+	}
+	budget.update(rateLimitedResponse(0, reset))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, budget.wait(context.Background()))
+		}()
+	}
+
+	// Every waiter sleeps until the reset, none is let through early.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(slept) == 4
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second, 30 * time.Second, 30 * time.Second}, slept)
+	now = reset
+	mu.Unlock()
+	close(release)
+	wg.Wait()
+
+	assert.Len(t, slept, 4)
+}
+
+func TestConcurrency(t *testing.T) {
+	assert.Equal(t, DefaultConcurrency, concurrency(0))
+	assert.Equal(t, DefaultConcurrency, concurrency(-1))
+	assert.Equal(t, 2, concurrency(2))
+}
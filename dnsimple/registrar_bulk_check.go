@@ -0,0 +1,251 @@
+package dnsimple
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BulkDomainCheckOptions specifies the optional parameters you can provide
+// to customize the RegistrarService.CheckDomains method.
+type BulkDomainCheckOptions struct {
+	// The number of domains checked at the same time. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// Skip the GetDomainPrices calls, leaving the prices of the results empty.
+	SkipPrices bool
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+}
+
+// DomainCandidateOptions specifies the TLDs to combine with the candidate names
+// in RegistrarService.CheckDomainCandidates.
+type DomainCandidateOptions struct {
+	// The TLDs to check, such as "com" or "co.uk". Defaults to every TLD.
+	Tlds []string
+
+	// The number of years the domains would be registered for.
+	// If set, the TLDs with a longer MinimumRegistration are excluded.
+	Years int
+
+	BulkDomainCheckOptions
+}
+
+// BulkDomainCheck represents the availability and the prices of a domain.
+type BulkDomainCheck struct {
+	Domain            string  `json:"domain"`
+	Available         bool    `json:"available"`
+	Premium           bool    `json:"premium"`
+	RegistrationPrice float64 `json:"registration_price,omitempty"`
+	RenewalPrice      float64 `json:"renewal_price,omitempty"`
+	TransferPrice     float64 `json:"transfer_price,omitempty"`
+	// The error checking the domain or fetching its prices, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// priced reports whether the prices of the domain are known.
+func (c *BulkDomainCheck) priced() bool {
+	return c.Available && c.Error == "" && c.RegistrationPrice > 0
+}
+
+// BulkDomainChecks represents the results of RegistrarService.CheckDomains.
+type BulkDomainChecks []BulkDomainCheck
+
+// Available returns the domains that are available, in the same order.
+func (c BulkDomainChecks) Available() BulkDomainChecks {
+	available := BulkDomainChecks{}
+	for _, check := range c {
+		if check.Available && check.Error == "" {
+			available = append(available, check)
+		}
+	}
+	return available
+}
+
+// SortByDomain sorts the results by domain name.
+func (c BulkDomainChecks) SortByDomain() {
+	sort.SliceStable(c, func(i, j int) bool { return c[i].Domain < c[j].Domain })
+}
+
+// SortByRegistrationPrice sorts the results from the cheapest registration price.
+// The results without a price, such as unavailable domains, are sorted last by domain name.
+func (c BulkDomainChecks) SortByRegistrationPrice() {
+	sort.SliceStable(c, func(i, j int) bool {
+		if c[i].priced() != c[j].priced() {
+			return c[i].priced()
+		}
+		if c[i].priced() && c[i].RegistrationPrice != c[j].RegistrationPrice {
+			return c[i].RegistrationPrice < c[j].RegistrationPrice
+		}
+		return c[i].Domain < c[j].Domain
+	})
+}
+
+// CheckDomains checks the availability and fetches the prices of many domains,
+// with CheckDomain and GetDomainPrices.
+//
+// The domain names are compared ignoring case and each domain is checked once.
+// The results are in the order of the first occurrence of each domain name.
+// The errors checking a single domain are reported in its result, only a cancelled
+// context stops the checks.
+func (s *RegistrarService) CheckDomains(ctx context.Context, accountID string, domainNames []string, options *BulkDomainCheckOptions) (BulkDomainChecks, error) {
+	checkOptions := BulkDomainCheckOptions{}
+	if options != nil {
+		checkOptions = *options
+	}
+	checkOptions.Concurrency = concurrency(checkOptions.Concurrency)
+
+	seen := map[string]bool{}
+	results := BulkDomainChecks{}
+	for _, domainName := range domainNames {
+		domainName = strings.ToLower(strings.Trim(strings.TrimSpace(domainName), "."))
+		if domainName == "" || seen[domainName] {
+			continue
+		}
+		seen[domainName] = true
+		results = append(results, BulkDomainCheck{Domain: domainName})
+	}
+
+	budget := newRateBudget(int(checkOptions.RateLimitReserve))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < checkOptions.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				s.checkDomain(ctx, accountID, &results[index], checkOptions.SkipPrices, budget)
+			}
+		}()
+	}
+	for index := range results {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *RegistrarService) checkDomain(ctx context.Context, accountID string, result *BulkDomainCheck, skipPrices bool, budget *rateBudget) {
+	if err := budget.wait(ctx); err != nil {
+		result.Error = err.Error()
+		return
+	}
+	checkResponse, err := s.CheckDomain(ctx, accountID, result.Domain)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	budget.update(&checkResponse.Response)
+	result.Available = checkResponse.Data.Available
+	result.Premium = checkResponse.Data.Premium
+
+	if !result.Available || skipPrices {
+		return
+	}
+
+	if err := budget.wait(ctx); err != nil {
+		result.Error = err.Error()
+		return
+	}
+	pricesResponse, err := s.GetDomainPrices(ctx, accountID, result.Domain)
+	if err != nil {
+		result.Error = err.Error()
+		return
+	}
+	budget.update(&pricesResponse.Response)
+	result.Premium = result.Premium || pricesResponse.Data.Premium
+	result.RegistrationPrice = pricesResponse.Data.RegistrationPrice
+	result.RenewalPrice = pricesResponse.Data.RenewalPrice
+	result.TransferPrice = pricesResponse.Data.TransferPrice
+}
+
+// CheckDomainCandidates checks every combination of the candidate names with the TLDs
+// that can be registered, see DomainCandidates and CheckDomains.
+func (s *RegistrarService) CheckDomainCandidates(ctx context.Context, accountID string, names []string, options *DomainCandidateOptions) (BulkDomainChecks, error) {
+	candidateOptions := DomainCandidateOptions{}
+	if options != nil {
+		candidateOptions = *options
+	}
+
+	tlds, err := s.client.Tlds.listAllTlds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domainNames := DomainCandidates(names, tlds, &candidateOptions)
+	return s.CheckDomains(ctx, accountID, domainNames, &candidateOptions.BulkDomainCheckOptions)
+}
+
+// DomainCandidates combines the candidate names with the TLDs that are enabled for registration.
+// With options, only the TLDs in options.Tlds are used, and the TLDs with a MinimumRegistration
+// longer than options.Years are excluded.
+//
+// The names can be labels such as "example", or domain names such as "example.com"
+// that are used as they are. The domain names come first, followed by the combinations
+// sorted by label and then by TLD, without duplicates.
+func DomainCandidates(names []string, tlds []Tld, options *DomainCandidateOptions) []string {
+	candidateOptions := DomainCandidateOptions{}
+	if options != nil {
+		candidateOptions = *options
+	}
+
+	wanted := map[string]bool{}
+	for _, tld := range candidateOptions.Tlds {
+		wanted[normalizeTld(tld)] = true
+	}
+
+	var suffixes []string
+	for _, tld := range tlds {
+		if !tld.RegistrationEnabled {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[normalizeTld(tld.Tld)] {
+			continue
+		}
+		if candidateOptions.Years > 0 && tld.MinimumRegistration > candidateOptions.Years {
+			continue
+		}
+		suffixes = append(suffixes, normalizeTld(tld.Tld))
+	}
+	sort.Strings(suffixes)
+
+	seen := map[string]bool{}
+	var candidates []string
+	add := func(domainName string) {
+		if !seen[domainName] {
+			seen[domainName] = true
+			candidates = append(candidates, domainName)
+		}
+	}
+
+	var labels []string
+	for _, name := range names {
+		name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "."))
+		switch {
+		case name == "":
+		case strings.Contains(name, "."):
+			add(name)
+		default:
+			labels = append(labels, name)
+		}
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		for _, suffix := range suffixes {
+			add(label + "." + suffix)
+		}
+	}
+
+	return candidates
+}
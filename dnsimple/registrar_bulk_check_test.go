@@ -0,0 +1,155 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// registerFakeRegistrarChecks serves CheckDomain and GetDomainPrices for the account 1010:
+// the domains starting with "taken" are not available, the ones starting with "broken" fail,
+// and the .io domains are premium. It returns the number of checks per domain.
+func registerFakeRegistrarChecks(t *testing.T) map[string]int {
+	var mu sync.Mutex
+	checks := map[string]int{}
+
+	mux.HandleFunc("/v2/1010/registrar/domains/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/1010/registrar/domains/"), "/")
+		domainName, action := parts[0], parts[1]
+		if strings.HasPrefix(domainName, "broken") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"message":"TLD not supported"}`)
+			return
+		}
+
+		premium := strings.HasSuffix(domainName, ".io")
+		switch action {
+		case "check":
+			mu.Lock()
+			checks[domainName]++
+			mu.Unlock()
+			check := DomainCheck{Domain: domainName, Available: !strings.HasPrefix(domainName, "taken"), Premium: premium}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": check})
+		case "prices":
+			price := DomainPrice{Domain: domainName, Premium: premium, RegistrationPrice: 14, RenewalPrice: 14, TransferPrice: 14}
+			if premium {
+				price.RegistrationPrice, price.RenewalPrice = 150, 150
+			} else if strings.HasSuffix(domainName, ".dev") {
+				price.RegistrationPrice = 12
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": price})
+		default:
+			t.Errorf("unexpected request %v", r.URL.Path)
+		}
+	})
+
+	return checks
+}
+
+func TestRegistrarService_CheckDomains(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	checks := registerFakeRegistrarChecks(t)
+
+	results, err := client.Registrar.CheckDomains(context.Background(), "1010", []string{"example.io", "Example.com", "taken.com", "example.com.", "broken.com", "example.dev", ""}, &BulkDomainCheckOptions{Concurrency: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"example.io": 1, "example.com": 1, "taken.com": 1, "example.dev": 1}, checks)
+	assert.Equal(t, BulkDomainChecks{
+		{Domain: "example.io", Available: true, Premium: true, RegistrationPrice: 150, RenewalPrice: 150, TransferPrice: 14},
+		{Domain: "example.com", Available: true, RegistrationPrice: 14, RenewalPrice: 14, TransferPrice: 14},
+		{Domain: "taken.com"},
+		{Domain: "broken.com", Error: results[3].Error},
+		{Domain: "example.dev", Available: true, RegistrationPrice: 12, RenewalPrice: 14, TransferPrice: 14},
+	}, results)
+	assert.Contains(t, results[3].Error, "TLD not supported")
+
+	results.SortByRegistrationPrice()
+	assert.Equal(t, []string{"example.dev", "example.com", "example.io", "broken.com", "taken.com"}, bulkCheckDomains(results))
+
+	results.SortByDomain()
+	assert.Equal(t, []string{"broken.com", "example.com", "example.dev", "example.io", "taken.com"}, bulkCheckDomains(results))
+
+	assert.Equal(t, []string{"example.com", "example.dev", "example.io"}, bulkCheckDomains(results.Available()))
+}
+
+func TestRegistrarService_CheckDomains_SkipPrices(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	registerFakeRegistrarChecks(t)
+
+	results, err := client.Registrar.CheckDomains(context.Background(), "1010", []string{"example.io"}, &BulkDomainCheckOptions{SkipPrices: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, BulkDomainChecks{{Domain: "example.io", Available: true, Premium: true}}, results)
+}
+
+func TestRegistrarService_CheckDomains_Cancelled(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Registrar.CheckDomains(ctx, "1010", []string{"example.com"}, nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRegistrarService_CheckDomainCandidates(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	registerFakeRegistrarChecks(t)
+	mux.HandleFunc("/v2/tlds", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"data":[{"tld":"com","minimum_registration":1,"registration_enabled":true},{"tld":"dev","minimum_registration":1,"registration_enabled":true}],"pagination":{"current_page":1,"per_page":2,"total_entries":4,"total_pages":2}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"tld":"io","minimum_registration":1,"registration_enabled":false},{"tld":"ai","minimum_registration":2,"registration_enabled":true}],"pagination":{"current_page":2,"per_page":2,"total_entries":4,"total_pages":2}}`)
+		default:
+			t.Errorf("unexpected page %v", r.URL.Query().Get("page"))
+		}
+	})
+
+	results, err := client.Registrar.CheckDomainCandidates(context.Background(), "1010", []string{"taken", "example"}, &DomainCandidateOptions{Years: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"example.com", "example.dev", "taken.com", "taken.dev"}, bulkCheckDomains(results))
+}
+
+func TestDomainCandidates(t *testing.T) {
+	tlds := []Tld{
+		{Tld: "com", MinimumRegistration: 1, RegistrationEnabled: true},
+		{Tld: "co.uk", MinimumRegistration: 1, RegistrationEnabled: true},
+		{Tld: "ai", MinimumRegistration: 2, RegistrationEnabled: true},
+		{Tld: "io", MinimumRegistration: 1, RegistrationEnabled: false},
+	}
+
+	assert.Equal(t, []string{"other.net", "alpha.ai", "alpha.co.uk", "alpha.com", "beta.ai", "beta.co.uk", "beta.com"},
+		DomainCandidates([]string{"Beta", "alpha", "other.net", "beta", " "}, tlds, nil))
+	assert.Equal(t, []string{"alpha.co.uk", "alpha.com"},
+		DomainCandidates([]string{"alpha"}, tlds, &DomainCandidateOptions{Years: 1}))
+	assert.Equal(t, []string{"alpha.ai"},
+		DomainCandidates([]string{"alpha"}, tlds, &DomainCandidateOptions{Tlds: []string{".AI", "io"}}))
+}
+
+func bulkCheckDomains(results BulkDomainChecks) []string {
+	domains := make([]string, 0, len(results))
+	for _, result := range results {
+		domains = append(domains, result.Domain)
+	}
+	return domains
+}
@@ -0,0 +1,349 @@
+package dnsimple
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DelegationResolver queries the DNS for the name servers of a domain.
+type DelegationResolver interface {
+	// ParentNS returns the name servers the parent zone delegates the domain to.
+	ParentNS(ctx context.Context, domainName string) ([]string, error)
+
+	// ServerNS queries a name server for the NS records of the domain, and reports
+	// whether the server answered authoritatively.
+	ServerNS(ctx context.Context, server string, domainName string) (nameServers []string, authoritative bool, err error)
+}
+
+// DNSDelegationResolver is a DelegationResolver that sends the queries to the name servers
+// of the parent zone and of the domain directly, without recursion.
+type DNSDelegationResolver struct {
+	// The resolver used to find the name servers of the parent zone, and the addresses
+	// of the name servers. Defaults to net.DefaultResolver.
+	Resolver *net.Resolver
+
+	// Dial, if set, is used to connect to the name servers instead of a net.Dialer.
+	// The address is an IP address with the port 53.
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// The timeout of each query. Defaults to 5 seconds.
+	Timeout time.Duration
+}
+
+// ParentNS implements DelegationResolver.
+//
+// The name servers of the parent zone, such as "com" for "example.com", are queried in turn
+// until one of them answers with the delegation of the domain.
+func (r *DNSDelegationResolver) ParentNS(ctx context.Context, domainName string) ([]string, error) {
+	parent := domainTld(domainName)
+	parentServers, err := r.resolver().LookupNS(ctx, parent)
+	if err != nil {
+		return nil, fmt.Errorf("looking up the name servers of %v: %w", parent, err)
+	}
+
+	err = fmt.Errorf("no name servers found for %v", parent)
+	for _, parentServer := range parentServers {
+		msg, queryErr := r.queryServer(ctx, parentServer.Host, domainName)
+		if queryErr != nil {
+			err = queryErr
+			continue
+		}
+
+		// The parent answers with a referral, in the authority section.
+		nameServers := nsRecords(msg.Authorities, domainName)
+		nameServers = append(nameServers, nsRecords(msg.Answers, domainName)...)
+		if len(nameServers) == 0 {
+			err = fmt.Errorf("%v: no delegation found for %v", normalizeNameServer(parentServer.Host), domainName)
+			continue
+		}
+		return uniqueNameServers(nameServers), nil
+	}
+	return nil, err
+}
+
+// ServerNS implements DelegationResolver.
+func (r *DNSDelegationResolver) ServerNS(ctx context.Context, server string, domainName string) ([]string, bool, error) {
+	msg, err := r.queryServer(ctx, server, domainName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nameServers := uniqueNameServers(nsRecords(msg.Answers, domainName))
+	return nameServers, msg.Header.Authoritative && len(nameServers) > 0, nil
+}
+
+func (r *DNSDelegationResolver) resolver() *net.Resolver {
+	if r.Resolver != nil {
+		return r.Resolver
+	}
+	return net.DefaultResolver
+}
+
+// queryServer sends a NS query for the domain to each address of the server,
+// until one of them answers.
+func (r *DNSDelegationResolver) queryServer(ctx context.Context, server string, domainName string) (*dnsmessage.Message, error) {
+	addresses, err := r.resolver().LookupHost(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range addresses {
+		var msg *dnsmessage.Message
+		msg, err = r.query(ctx, net.JoinHostPort(address, "53"), domainName)
+		if err == nil {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%v: %w", normalizeNameServer(server), err)
+}
+
+// query sends a NS query for the domain to the address over UDP,
+// and again over TCP if the answer is truncated.
+func (r *DNSDelegationResolver) query(ctx context.Context, address string, domainName string) (*dnsmessage.Message, error) {
+	name, err := dnsmessage.NewName(strings.TrimSuffix(domainName, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	question := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: binary.BigEndian.Uint16(id[:])},
+		Questions: []dnsmessage.Question{{Name: name, Type: dnsmessage.TypeNS, Class: dnsmessage.ClassINET}},
+	}
+	packed, err := question.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := r.exchange(ctx, "udp", address, packed)
+	if err == nil && msg.Header.Truncated {
+		msg, err = r.exchange(ctx, "tcp", address, packed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Header.ID != question.Header.ID || !msg.Header.Response {
+		return nil, errors.New("invalid DNS response")
+	}
+	if msg.Header.RCode != dnsmessage.RCodeSuccess {
+		return nil, fmt.Errorf("DNS query for %v failed: %v", domainName, msg.Header.RCode)
+	}
+	return msg, nil
+}
+
+func (r *DNSDelegationResolver) exchange(ctx context.Context, network string, address string, packed []byte) (*dnsmessage.Message, error) {
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dial := r.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	conn, err := dial(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var response []byte
+	if network == "tcp" {
+		length := make([]byte, 2)
+		binary.BigEndian.PutUint16(length, uint16(len(packed)))
+		if _, err := conn.Write(append(length, packed...)); err != nil {
+			return nil, err
+		}
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return nil, err
+		}
+		response = make([]byte, binary.BigEndian.Uint16(length))
+		if _, err := io.ReadFull(conn, response); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := conn.Write(packed); err != nil {
+			return nil, err
+		}
+		response = make([]byte, 1232)
+		n, err := conn.Read(response)
+		if err != nil {
+			return nil, err
+		}
+		response = response[:n]
+	}
+
+	msg := &dnsmessage.Message{}
+	if err := msg.Unpack(response); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// nsRecords returns the name servers of the NS records of the domain in the resources.
+func nsRecords(resources []dnsmessage.Resource, domainName string) []string {
+	var nameServers []string
+	for _, resource := range resources {
+		ns, ok := resource.Body.(*dnsmessage.NSResource)
+		if !ok || normalizeNameServer(resource.Header.Name.String()) != normalizeNameServer(domainName) {
+			continue
+		}
+		nameServers = append(nameServers, normalizeNameServer(ns.NS.String()))
+	}
+	return nameServers
+}
+
+// normalizeNameServer returns the name in lower case, without the trailing dot.
+func normalizeNameServer(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// uniqueNameServers returns the normalized names, sorted and without duplicates.
+func uniqueNameServers(names []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, name := range names {
+		name = normalizeNameServer(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// LameNameServer represents a name server that doesn't answer authoritatively for a domain.
+type LameNameServer struct {
+	Server string
+	Reason string
+}
+
+// DelegationCheck represents the comparison of the delegation of a domain at the registrar,
+// at the parent zone and in the zone.
+//
+// The name servers are in lower case, without the trailing dot.
+type DelegationCheck struct {
+	Domain string
+
+	// The name servers of the delegation at the registrar, see GetDomainDelegation.
+	Delegation []string
+	// The name servers the parent zone delegates the domain to.
+	Parent []string
+	// The NS records at the apex of the zone. Nil when the domain has no zone in the account.
+	Zone []string
+
+	// The name servers of the Delegation the parent zone doesn't delegate to.
+	Missing []string
+	// The name servers the parent zone delegates to that are not in the Delegation.
+	Unexpected []string
+	// The name servers of the Delegation without a NS record in the Zone.
+	NotInZone []string
+	// The NS records of the Zone that are not in the Delegation.
+	NotDelegated []string
+	// The name servers of the Delegation or the Parent that don't answer authoritatively for the domain.
+	Lame []LameNameServer
+}
+
+// OK reports whether the delegation matches everywhere, and every name server answers authoritatively.
+func (c *DelegationCheck) OK() bool {
+	return len(c.Missing) == 0 && len(c.Unexpected) == 0 && len(c.NotInZone) == 0 && len(c.NotDelegated) == 0 && len(c.Lame) == 0
+}
+
+// CheckDomainDelegation compares the delegation of a domain at the registrar with the delegation
+// at the parent zone and with the NS records of the zone, and queries each name server to find
+// the lame ones.
+//
+// The DNS queries are sent with the resolver, or a DNSDelegationResolver when nil.
+func (s *RegistrarService) CheckDomainDelegation(ctx context.Context, accountID string, domainName string, resolver DelegationResolver) (*DelegationCheck, error) {
+	if resolver == nil {
+		resolver = &DNSDelegationResolver{}
+	}
+	check := &DelegationCheck{Domain: domainName}
+
+	delegationResponse, err := s.GetDomainDelegation(ctx, accountID, domainName)
+	if err != nil {
+		return nil, err
+	}
+	if delegationResponse.Data != nil {
+		check.Delegation = uniqueNameServers(*delegationResponse.Data)
+	}
+
+	records, err := s.client.Zones.listAllRecords(ctx, accountID, domainName, &ZoneRecordListOptions{Type: String("NS")})
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return nil, err
+	default:
+		var zoneNameServers []string
+		for _, record := range records {
+			if record.Name == "" {
+				zoneNameServers = append(zoneNameServers, record.Content)
+			}
+		}
+		check.Zone = uniqueNameServers(zoneNameServers)
+	}
+
+	check.Parent, err = resolver.ParentNS(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	check.Missing = nameServersDifference(check.Delegation, check.Parent)
+	check.Unexpected = nameServersDifference(check.Parent, check.Delegation)
+	if check.Zone != nil {
+		check.NotInZone = nameServersDifference(check.Delegation, check.Zone)
+		check.NotDelegated = nameServersDifference(check.Zone, check.Delegation)
+	}
+
+	for _, server := range uniqueNameServers(append(append([]string{}, check.Delegation...), check.Parent...)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, authoritative, err := resolver.ServerNS(ctx, server, domainName)
+		switch {
+		case err != nil:
+			check.Lame = append(check.Lame, LameNameServer{Server: server, Reason: err.Error()})
+		case !authoritative:
+			check.Lame = append(check.Lame, LameNameServer{Server: server, Reason: "not authoritative for " + domainName})
+		}
+	}
+
+	return check, nil
+}
+
+// nameServersDifference returns the name servers of a that are not in b.
+func nameServersDifference(a []string, b []string) []string {
+	in := map[string]bool{}
+	for _, name := range b {
+		in[name] = true
+	}
+
+	var difference []string
+	for _, name := range a {
+		if !in[name] {
+			difference = append(difference, name)
+		}
+	}
+	return difference
+}
@@ -0,0 +1,209 @@
+package dnsimple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// fakeDelegationResolver is a DelegationResolver with canned answers.
+type fakeDelegationResolver struct {
+	parent        []string
+	authoritative map[string]bool
+	failing       map[string]bool
+}
+
+func (r *fakeDelegationResolver) ParentNS(ctx context.Context, domainName string) ([]string, error) {
+	return r.parent, nil
+}
+
+func (r *fakeDelegationResolver) ServerNS(ctx context.Context, server string, domainName string) ([]string, bool, error) {
+	if r.failing[server] {
+		return nil, false, errors.New("i/o timeout")
+	}
+	return r.parent, r.authoritative[server], nil
+}
+
+func TestRegistrarService_CheckDomainDelegation(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/domains/example.com/delegation", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":["ns1.dnsimple.com","NS2.dnsimple.com.","ns3.dnsimple.com"]}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, map[string][]string{"type": {"NS"}, "page": {"1"}})
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"","type":"NS","content":"ns1.dnsimple.com"},
+			{"id":2,"name":"","type":"NS","content":"ns2.dnsimple.com"},
+			{"id":3,"name":"","type":"NS","content":"ns4.dnsimple.com"},
+			{"id":4,"name":"sub","type":"NS","content":"ns3.dnsimple.com"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":4,"total_pages":1}}`)
+	})
+
+	resolver := &fakeDelegationResolver{
+		parent:        []string{"ns1.dnsimple.com", "ns2.dnsimple.com", "ns.other.net"},
+		authoritative: map[string]bool{"ns1.dnsimple.com": true, "ns3.dnsimple.com": true},
+		failing:       map[string]bool{"ns.other.net": true},
+	}
+	check, err := client.Registrar.CheckDomainDelegation(context.Background(), "1010", "example.com", resolver)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1.dnsimple.com", "ns2.dnsimple.com", "ns3.dnsimple.com"}, check.Delegation)
+	assert.Equal(t, []string{"ns1.dnsimple.com", "ns2.dnsimple.com", "ns4.dnsimple.com"}, check.Zone)
+	assert.Equal(t, []string{"ns3.dnsimple.com"}, check.Missing)
+	assert.Equal(t, []string{"ns.other.net"}, check.Unexpected)
+	assert.Equal(t, []string{"ns3.dnsimple.com"}, check.NotInZone)
+	assert.Equal(t, []string{"ns4.dnsimple.com"}, check.NotDelegated)
+	assert.Equal(t, []LameNameServer{
+		{Server: "ns.other.net", Reason: "i/o timeout"},
+		{Server: "ns2.dnsimple.com", Reason: "not authoritative for example.com"},
+	}, check.Lame)
+	assert.False(t, check.OK())
+}
+
+func TestRegistrarService_CheckDomainDelegation_WithoutZone(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/domains/example.com/delegation", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/getDomainDelegation/success.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/notfound-zone.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	delegation := []string{"ns1.dnsimple.com", "ns2.dnsimple.com", "ns3.dnsimple.com", "ns4.dnsimple.com"}
+	resolver := &fakeDelegationResolver{parent: delegation, authoritative: map[string]bool{}}
+	for _, server := range delegation {
+		resolver.authoritative[server] = true
+	}
+	check, err := client.Registrar.CheckDomainDelegation(context.Background(), "1010", "example.com", resolver)
+
+	assert.NoError(t, err)
+	assert.Equal(t, delegation, check.Delegation)
+	assert.Nil(t, check.Zone)
+	assert.True(t, check.OK())
+}
+
+// startDNSStub serves DNS queries on a local UDP port with the answers of the handler.
+func startDNSStub(t *testing.T, handler func(question dnsmessage.Question) dnsmessage.Message) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+
+			response := handler(query.Questions[0])
+			response.Header.ID = query.Header.ID
+			response.Header.Response = true
+			response.Questions = query.Questions
+			packed, err := response.Pack()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func nsResource(name string, ns string) dnsmessage.Resource {
+	return dnsmessage.Resource{
+		Header: dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Type: dnsmessage.TypeNS, Class: dnsmessage.ClassINET, TTL: 3600},
+		Body:   &dnsmessage.NSResource{NS: dnsmessage.MustNewName(ns)},
+	}
+}
+
+func TestDNSDelegationResolver(t *testing.T) {
+	addresses := map[string][4]byte{
+		"ns.parent.test.":  {192, 0, 2, 1},
+		"ns1.example.com.": {192, 0, 2, 11},
+		"ns2.example.com.": {192, 0, 2, 12},
+	}
+	recursive := startDNSStub(t, func(question dnsmessage.Question) dnsmessage.Message {
+		response := dnsmessage.Message{Header: dnsmessage.Header{RecursionAvailable: true}}
+		switch {
+		case question.Type == dnsmessage.TypeNS && question.Name.String() == "com.":
+			response.Answers = []dnsmessage.Resource{nsResource("com.", "ns.parent.test.")}
+		case question.Type == dnsmessage.TypeA:
+			if address, ok := addresses[question.Name.String()]; ok {
+				response.Answers = []dnsmessage.Resource{{
+					Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 3600},
+					Body:   &dnsmessage.AResource{A: address},
+				}}
+			}
+		}
+		return response
+	})
+	parent := startDNSStub(t, func(question dnsmessage.Question) dnsmessage.Message {
+		return dnsmessage.Message{Authorities: []dnsmessage.Resource{
+			nsResource("example.com.", "NS1.example.com."),
+			nsResource("example.com.", "ns2.example.com."),
+		}}
+	})
+	authoritative := startDNSStub(t, func(question dnsmessage.Question) dnsmessage.Message {
+		return dnsmessage.Message{Header: dnsmessage.Header{Authoritative: true}, Answers: []dnsmessage.Resource{
+			nsResource("example.com.", "ns1.example.com."),
+			nsResource("example.com.", "ns2.example.com."),
+		}}
+	})
+	lame := startDNSStub(t, func(question dnsmessage.Question) dnsmessage.Message {
+		return dnsmessage.Message{Header: dnsmessage.Header{RCode: dnsmessage.RCodeRefused}}
+	})
+
+	stubs := map[string]string{"192.0.2.1:53": parent, "192.0.2.11:53": authoritative, "192.0.2.12:53": lame}
+	resolver := &DNSDelegationResolver{
+		Resolver: &net.Resolver{PreferGo: true, Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "udp", recursive)
+		}},
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, stubs[address])
+		},
+	}
+
+	nameServers, err := resolver.ParentNS(context.Background(), "example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, nameServers)
+
+	nameServers, isAuthoritative, err := resolver.ServerNS(context.Background(), "ns1.example.com", "example.com")
+	assert.NoError(t, err)
+	assert.True(t, isAuthoritative)
+	assert.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, nameServers)
+
+	_, _, err = resolver.ServerNS(context.Background(), "ns2.example.com", "example.com")
+	assert.EqualError(t, err, "ns2.example.com: DNS query for example.com failed: RCodeRefused")
+
+	_, isAuthoritative, err = resolver.ServerNS(context.Background(), "ns.parent.test", "example.com")
+	assert.NoError(t, err)
+	assert.False(t, isAuthoritative)
+}
@@ -0,0 +1,134 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DomainRegistrationProblem represents a reason why a domain can't be registered.
+type DomainRegistrationProblem struct {
+	// The input field the problem relates to, such as "registrant_id", "premium_price"
+	// or "extended_attributes.uk_legal_type". "domain" for problems with the domain itself.
+	Field   string
+	Message string
+}
+
+// DomainRegistrationValidationError is returned by RegistrarService.GuidedRegisterDomain
+// when the registration input is not valid. No registration is attempted.
+type DomainRegistrationValidationError struct {
+	Domain   string
+	Problems []DomainRegistrationProblem
+}
+
+// Error implements the error interface.
+func (e *DomainRegistrationValidationError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, fmt.Sprintf("%v: %v", problem.Field, problem.Message))
+	}
+	return fmt.Sprintf("cannot register %v: %v", e.Domain, strings.Join(problems, "; "))
+}
+
+// GuidedDomainRegistration represents the outcome of each step of RegistrarService.GuidedRegisterDomain.
+// The fields of the steps that were not reached are nil.
+type GuidedDomainRegistration struct {
+	Check              *DomainCheck
+	Prices             *DomainPrice
+	ExtendedAttributes []TldExtendedAttribute
+	Registrant         *Contact
+	Registration       *DomainRegistration
+}
+
+// GuidedRegisterDomain registers a domain name after validating the input:
+//
+//   - the domain must be available, see CheckDomain
+//   - the price of a premium domain must be confirmed, by setting input.PremiumPrice
+//     to the registration price returned by GetDomainPrices
+//   - the extended attributes must be valid for the TLD, see CheckExtendedAttributes
+//   - the registrant contact must exist in the account
+//
+// When the input is not valid a *DomainRegistrationValidationError listing every problem is returned,
+// together with the outcome of the steps run so far.
+func (s *RegistrarService) GuidedRegisterDomain(ctx context.Context, accountID string, domainName string, input *RegisterDomainInput) (*GuidedDomainRegistration, error) {
+	result := &GuidedDomainRegistration{}
+	invalid := &DomainRegistrationValidationError{Domain: domainName}
+	problem := func(field string, format string, args ...interface{}) {
+		invalid.Problems = append(invalid.Problems, DomainRegistrationProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if input == nil {
+		input = &RegisterDomainInput{}
+	}
+	if input.RegistrantID <= 0 {
+		problem("registrant_id", "is required")
+		return result, invalid
+	}
+
+	checkResponse, err := s.CheckDomain(ctx, accountID, domainName)
+	if err != nil {
+		return result, err
+	}
+	result.Check = checkResponse.Data
+	if !result.Check.Available {
+		problem("domain", "is not available")
+		return result, invalid
+	}
+
+	pricesResponse, err := s.GetDomainPrices(ctx, accountID, domainName)
+	if err != nil {
+		return result, err
+	}
+	result.Prices = pricesResponse.Data
+	if result.Check.Premium || result.Prices.Premium {
+		price := result.Prices.RegistrationPrice
+		if input.PremiumPrice == "" {
+			problem("premium_price", "the domain is premium, the registration price %.2f must be confirmed", price)
+		} else if confirmed, err := strconv.ParseFloat(input.PremiumPrice, 64); err != nil {
+			problem("premium_price", "%q is not a price", input.PremiumPrice)
+		} else if math.Abs(confirmed-price) >= 0.005 {
+			problem("premium_price", "the confirmed price %v does not match the registration price %.2f", input.PremiumPrice, price)
+		}
+	}
+
+	attributes, err := s.client.Tlds.cachedExtendedAttributes(ctx, domainTld(domainName))
+	if err != nil {
+		return result, err
+	}
+	result.ExtendedAttributes = attributes
+	for _, attributeProblem := range CheckExtendedAttributes(attributes, input.ExtendedAttributes) {
+		problem("extended_attributes."+attributeProblem.Name, "%v", attributeProblem.Message)
+	}
+
+	contactResponse, err := s.client.Contacts.GetContact(ctx, accountID, int64(input.RegistrantID))
+	switch {
+	case isNotFound(err):
+		problem("registrant_id", "contact %d does not exist", input.RegistrantID)
+	case err != nil:
+		return result, err
+	default:
+		result.Registrant = contactResponse.Data
+	}
+
+	if len(invalid.Problems) > 0 {
+		return result, invalid
+	}
+
+	registrationResponse, err := s.RegisterDomain(ctx, accountID, domainName, input)
+	if err != nil {
+		return result, err
+	}
+	result.Registration = registrationResponse.Data
+	return result, nil
+}
+
+// domainTld returns the TLD a domain name is registered under, such as "com" or "co.uk".
+func domainTld(domainName string) string {
+	parts := strings.SplitN(strings.TrimSuffix(domainName, "."), ".", 2)
+	if len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[1]
+}
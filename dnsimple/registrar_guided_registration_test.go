@@ -0,0 +1,113 @@
+package dnsimple
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupGuidedRegistrationHandlers(t *testing.T, contactFixture string) *int {
+	registrations := 0
+	fixture := func(path string, method string, name string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			httpResponse := httpResponseFixture(t, name)
+
+			testMethod(t, r, method)
+			testHeaders(t, r)
+
+			if method == "POST" {
+				registrations++
+			}
+			w.WriteHeader(httpResponse.StatusCode)
+			_, _ = io.Copy(w, httpResponse.Body)
+		})
+	}
+
+	fixture("/v2/1010/registrar/domains/example.uk/check", "GET", "/api/checkDomain/success.http")
+	fixture("/v2/1010/registrar/domains/example.uk/prices", "GET", "/api/getDomainPrices/success.http")
+	fixture("/v2/tlds/uk/extended_attributes", "GET", "/api/getTldExtendedAttributes/success.http")
+	fixture("/v2/1010/contacts/2", "GET", contactFixture)
+	fixture("/v2/1010/registrar/domains/example.uk/registrations", "POST", "/api/registerDomain/success.http")
+	return &registrations
+}
+
+func TestRegistrarService_GuidedRegisterDomain(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	registrations := setupGuidedRegistrationHandlers(t, "/api/getContact/success.http")
+
+	input := &RegisterDomainInput{RegistrantID: 2, PremiumPrice: "20.00", ExtendedAttributes: map[string]string{"uk_legal_type": "LTD", "registered_for": "Example Ltd"}}
+	result, err := client.Registrar.GuidedRegisterDomain(context.Background(), "1010", "example.uk", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *registrations)
+	assert.True(t, result.Check.Available)
+	assert.Equal(t, 20.0, result.Prices.RegistrationPrice)
+	assert.Equal(t, "uk_legal_type", result.ExtendedAttributes[0].Name)
+	assert.Equal(t, int64(1), result.Registrant.ID)
+	assert.Equal(t, int64(1), result.Registration.ID)
+}
+
+func TestRegistrarService_GuidedRegisterDomain_Invalid(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	registrations := setupGuidedRegistrationHandlers(t, "/api/notfound-contact.http")
+
+	input := &RegisterDomainInput{RegistrantID: 2, ExtendedAttributes: map[string]string{"uk_legal_type": "ltd", "registered_for": "Example Ltd"}}
+	result, err := client.Registrar.GuidedRegisterDomain(context.Background(), "1010", "example.uk", input)
+
+	var invalid *DomainRegistrationValidationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, "example.uk", invalid.Domain)
+	assert.Equal(t, []string{"premium_price", "extended_attributes.uk_legal_type", "registrant_id"}, registrationProblemFields(invalid))
+	assert.Contains(t, err.Error(), `"ltd" is not one of IND, FIND, LTD`)
+	assert.Equal(t, 0, *registrations)
+	assert.NotNil(t, result.Prices)
+	assert.Nil(t, result.Registration)
+}
+
+func TestRegistrarService_GuidedRegisterDomain_PremiumPriceMismatch(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	registrations := setupGuidedRegistrationHandlers(t, "/api/getContact/success.http")
+
+	input := &RegisterDomainInput{RegistrantID: 2, PremiumPrice: "19.99", ExtendedAttributes: map[string]string{"registered_for": "Example Ltd"}}
+	_, err := client.Registrar.GuidedRegisterDomain(context.Background(), "1010", "example.uk", input)
+
+	var invalid *DomainRegistrationValidationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, []string{"premium_price"}, registrationProblemFields(invalid))
+	assert.Equal(t, 0, *registrations)
+}
+
+func TestRegistrarService_GuidedRegisterDomain_RegistrantRequired(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	_, err := client.Registrar.GuidedRegisterDomain(context.Background(), "1010", "example.uk", &RegisterDomainInput{})
+
+	var invalid *DomainRegistrationValidationError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, []string{"registrant_id"}, registrationProblemFields(invalid))
+}
+
+func TestDomainTld(t *testing.T) {
+	assert.Equal(t, "com", domainTld("example.com"))
+	assert.Equal(t, "co.uk", domainTld("example.co.uk."))
+	assert.Equal(t, "com", domainTld("com"))
+}
+
+func registrationProblemFields(err *DomainRegistrationValidationError) []string {
+	fields := make([]string, 0, len(err.Problems))
+	for _, problem := range err.Problems {
+		fields = append(fields, problem.Field)
+	}
+	return fields
+}
@@ -0,0 +1,200 @@
+package dnsimple
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// The states of a registrant change.
+const (
+	RegistrantChangeStateNew        = "new"
+	RegistrantChangeStatePending    = "pending"
+	RegistrantChangeStateCancelling = "cancelling"
+	RegistrantChangeStateCancelled  = "cancelled"
+	RegistrantChangeStateCompleted  = "completed"
+)
+
+// RegistrantChange represents a change of the registrant contact of a domain.
+type RegistrantChange struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	ContactID int64 `json:"contact_id"`
+	DomainID  int64 `json:"domain_id"`
+	// One of "new", "pending", "cancelling", "cancelled" or "completed".
+	State              string            `json:"state"`
+	ExtendedAttributes map[string]string `json:"extended_attributes"`
+	// Whether the registry considers the change a change of owner,
+	// that may require the confirmation of the registrant.
+	RegistryOwnerChange bool `json:"registry_owner_change"`
+	// Who lifted the inter-registrar transfer lock applied after the change, if any.
+	IrtLockLiftedBy string `json:"irt_lock_lifted_by,omitempty"`
+	CreatedAt       string `json:"created_at,omitempty"`
+	UpdatedAt       string `json:"updated_at,omitempty"`
+}
+
+// Pending reports whether the registrant change is still waiting to be completed or cancelled by the registry.
+func (c *RegistrantChange) Pending() bool {
+	switch c.State {
+	case RegistrantChangeStateNew, RegistrantChangeStatePending, RegistrantChangeStateCancelling:
+		return true
+	}
+	return false
+}
+
+// RegistrantChangeCheck represents the requirements to change the registrant contact of a domain.
+type RegistrantChangeCheck struct {
+	ContactID int64 `json:"contact_id"`
+	DomainID  int64 `json:"domain_id"`
+	// The extended attributes to provide when creating the registrant change,
+	// they can be checked with CheckExtendedAttributes.
+	ExtendedAttributes  []TldExtendedAttribute `json:"extended_attributes"`
+	RegistryOwnerChange bool                   `json:"registry_owner_change"`
+}
+
+// RegistrantChangeResponse represents a response from an API method that returns a RegistrantChange struct.
+type RegistrantChangeResponse struct {
+	Response
+	Data *RegistrantChange `json:"data"`
+}
+
+// RegistrantChangesResponse represents a response from an API method that returns a collection of RegistrantChange struct.
+type RegistrantChangesResponse struct {
+	Response
+	Data []RegistrantChange `json:"data"`
+}
+
+// RegistrantChangeCheckResponse represents a response from an API method that returns a RegistrantChangeCheck struct.
+type RegistrantChangeCheckResponse struct {
+	Response
+	Data *RegistrantChangeCheck `json:"data"`
+}
+
+// CheckRegistrantChangeInput represents the attributes you can pass to a registrant change check API request.
+type CheckRegistrantChangeInput struct {
+	// The ID or the name of the domain.
+	DomainID string `json:"domain_id"`
+	// The ID of the contact to use as the new registrant.
+	ContactID int64 `json:"contact_id"`
+}
+
+// CreateRegistrantChangeInput represents the attributes you can pass to a registrant change API request.
+type CreateRegistrantChangeInput struct {
+	// The ID or the name of the domain.
+	DomainID string `json:"domain_id"`
+	// The ID of the contact to use as the new registrant.
+	ContactID int64 `json:"contact_id"`
+	// The extended attributes required by the TLD, see CheckRegistrantChange.
+	ExtendedAttributes map[string]string `json:"extended_attributes,omitempty"`
+}
+
+// RegistrantChangeListOptions specifies the optional parameters you can provide
+// to customize the RegistrarService.ListRegistrantChanges method.
+type RegistrantChangeListOptions struct {
+	// Select the registrant changes in the given state.
+	State *string `url:"state,omitempty"`
+
+	// Select the registrant changes of the given domain.
+	DomainID *string `url:"domain_id,omitempty"`
+
+	// Select the registrant changes to the given contact.
+	ContactID *string `url:"contact_id,omitempty"`
+
+	ListOptions
+}
+
+// CheckRegistrantChange returns the requirements to change the registrant contact of a domain.
+//
+// See https://developer.dnsimple.com/v2/registrar/#checkRegistrantChange
+func (s *RegistrarService) CheckRegistrantChange(ctx context.Context, accountID string, input *CheckRegistrantChangeInput) (*RegistrantChangeCheckResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/registrant_changes/check", accountID))
+	checkResponse := &RegistrantChangeCheckResponse{}
+
+	resp, err := s.client.post(ctx, path, input, checkResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	checkResponse.HTTPResponse = resp
+	return checkResponse, nil
+}
+
+// CreateRegistrantChange starts the change of the registrant contact of a domain.
+//
+// See https://developer.dnsimple.com/v2/registrar/#createRegistrantChange
+func (s *RegistrarService) CreateRegistrantChange(ctx context.Context, accountID string, input *CreateRegistrantChangeInput) (*RegistrantChangeResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/registrant_changes", accountID))
+	changeResponse := &RegistrantChangeResponse{}
+
+	resp, err := s.client.post(ctx, path, input, changeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	changeResponse.HTTPResponse = resp
+	return changeResponse, nil
+}
+
+// GetRegistrantChange fetches a registrant change.
+//
+// See https://developer.dnsimple.com/v2/registrar/#getRegistrantChange
+func (s *RegistrarService) GetRegistrantChange(ctx context.Context, accountID string, registrantChangeID int64) (*RegistrantChangeResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/registrant_changes/%v", accountID, registrantChangeID))
+	changeResponse := &RegistrantChangeResponse{}
+
+	resp, err := s.client.get(ctx, path, changeResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	changeResponse.HTTPResponse = resp
+	return changeResponse, nil
+}
+
+// ListRegistrantChanges lists the registrant changes of an account.
+//
+// See https://developer.dnsimple.com/v2/registrar/#listRegistrantChanges
+func (s *RegistrarService) ListRegistrantChanges(ctx context.Context, accountID string, options *RegistrantChangeListOptions) (*RegistrantChangesResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/registrant_changes", accountID))
+	changesResponse := &RegistrantChangesResponse{}
+
+	path, err := addURLQueryOptions(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.get(ctx, path, changesResponse)
+	if err != nil {
+		return changesResponse, err
+	}
+
+	changesResponse.HTTPResponse = resp
+	return changesResponse, nil
+}
+
+// DeleteRegistrantChange cancels a registrant change.
+//
+// A change that can be cancelled right away is deleted, and the Data of the response is nil.
+// Otherwise the change is returned in the cancelling state, until the registry cancels it.
+//
+// See https://developer.dnsimple.com/v2/registrar/#deleteRegistrantChange
+func (s *RegistrarService) DeleteRegistrantChange(ctx context.Context, accountID string, registrantChangeID int64) (*RegistrantChangeResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/registrant_changes/%v", accountID, registrantChangeID))
+	changeResponse := &RegistrantChangeResponse{}
+
+	// The body is empty when the change is deleted right away.
+	body := &bytes.Buffer{}
+	resp, err := s.client.delete(ctx, path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if body.Len() > 0 {
+		if err := json.Unmarshal(body.Bytes(), changeResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	changeResponse.HTTPResponse = resp
+	return changeResponse, nil
+}
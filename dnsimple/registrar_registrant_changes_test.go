@@ -0,0 +1,169 @@
+package dnsimple
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrarService_CheckRegistrantChange(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes/check", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/checkRegistrantChange/success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		want := map[string]interface{}{"domain_id": "example.com", "contact_id": float64(101)}
+		testRequestJSON(t, r, want)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	checkResponse, err := client.Registrar.CheckRegistrantChange(context.Background(), "1010", &CheckRegistrantChangeInput{DomainID: "example.com", ContactID: 101})
+
+	assert.NoError(t, err)
+	check := checkResponse.Data
+	assert.Equal(t, int64(101), check.DomainID)
+	assert.Equal(t, int64(101), check.ContactID)
+	assert.True(t, check.RegistryOwnerChange)
+	assert.Len(t, check.ExtendedAttributes, 1)
+	assert.Equal(t, "x-au-registrant-id-type", check.ExtendedAttributes[0].Name)
+	assert.True(t, check.ExtendedAttributes[0].Required)
+	assert.Equal(t, "ABN", check.ExtendedAttributes[0].Options[0].Value)
+}
+
+func TestRegistrarService_CreateRegistrantChange(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/createRegistrantChange/success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		want := map[string]interface{}{
+			"domain_id":           "101",
+			"contact_id":          float64(101),
+			"extended_attributes": map[string]interface{}{"x-au-registrant-id-type": "ABN"},
+		}
+		testRequestJSON(t, r, want)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	input := &CreateRegistrantChangeInput{DomainID: "101", ContactID: 101, ExtendedAttributes: map[string]string{"x-au-registrant-id-type": "ABN"}}
+	changeResponse, err := client.Registrar.CreateRegistrantChange(context.Background(), "1010", input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, changeResponse.HTTPResponse.StatusCode)
+	change := changeResponse.Data
+	assert.Equal(t, &RegistrantChange{
+		ID:                  101,
+		AccountID:           101,
+		ContactID:           101,
+		DomainID:            101,
+		State:               "new",
+		ExtendedAttributes:  map[string]string{},
+		RegistryOwnerChange: true,
+		CreatedAt:           "2017-02-03T17:43:22Z",
+		UpdatedAt:           "2017-02-03T17:43:22Z",
+	}, change)
+	assert.True(t, change.Pending())
+}
+
+func TestRegistrarService_GetRegistrantChange(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes/101", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/getRegistrantChange/success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	changeResponse, err := client.Registrar.GetRegistrantChange(context.Background(), "1010", 101)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(101), changeResponse.Data.ID)
+	assert.Equal(t, RegistrantChangeStateNew, changeResponse.Data.State)
+}
+
+func TestRegistrarService_ListRegistrantChanges(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/listRegistrantChanges/success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+		testQuery(t, r, url.Values{"state": []string{"new"}, "domain_id": []string{"example.com"}, "page": []string{"2"}})
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	options := &RegistrantChangeListOptions{State: String("new"), DomainID: String("example.com"), ListOptions: ListOptions{Page: Int(2)}}
+	changesResponse, err := client.Registrar.ListRegistrantChanges(context.Background(), "1010", options)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Pagination{CurrentPage: 1, PerPage: 30, TotalPages: 1, TotalEntries: 1}, changesResponse.Pagination)
+	assert.Len(t, changesResponse.Data, 1)
+	assert.Equal(t, int64(101), changesResponse.Data[0].ID)
+}
+
+func TestRegistrarService_DeleteRegistrantChange(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes/101", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/deleteRegistrantChange/success.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	changeResponse, err := client.Registrar.DeleteRegistrantChange(context.Background(), "1010", 101)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, changeResponse.HTTPResponse.StatusCode)
+	assert.Nil(t, changeResponse.Data)
+}
+
+func TestRegistrarService_DeleteRegistrantChange_Async(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/registrant_changes/101", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/deleteRegistrantChange/success_async.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	changeResponse, err := client.Registrar.DeleteRegistrantChange(context.Background(), "1010", 101)
+
+	assert.NoError(t, err)
+	assert.Equal(t, RegistrantChangeStateCancelling, changeResponse.Data.State)
+	assert.True(t, changeResponse.Data.Pending())
+}
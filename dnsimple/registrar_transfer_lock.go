@@ -0,0 +1,66 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+)
+
+// TransferLock represents the transfer lock of a domain, that prevents the domain
+// from being transferred to another registrar.
+type TransferLock struct {
+	Enabled bool `json:"enabled"`
+}
+
+// TransferLockResponse represents a response from an API method that returns a TransferLock struct.
+type TransferLockResponse struct {
+	Response
+	Data *TransferLock `json:"data"`
+}
+
+// GetDomainTransferLock gets the transfer lock status for the domain.
+//
+// See https://developer.dnsimple.com/v2/registrar/#getDomainTransferLock
+func (s *RegistrarService) GetDomainTransferLock(ctx context.Context, accountID string, domainName string) (*TransferLockResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/domains/%v/transfer_lock", accountID, domainName))
+	transferLockResponse := &TransferLockResponse{}
+
+	resp, err := s.client.get(ctx, path, transferLockResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	transferLockResponse.HTTPResponse = resp
+	return transferLockResponse, nil
+}
+
+// EnableDomainTransferLock enables the transfer lock for the domain.
+//
+// See https://developer.dnsimple.com/v2/registrar/#enableDomainTransferLock
+func (s *RegistrarService) EnableDomainTransferLock(ctx context.Context, accountID string, domainName string) (*TransferLockResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/domains/%v/transfer_lock", accountID, domainName))
+	transferLockResponse := &TransferLockResponse{}
+
+	resp, err := s.client.post(ctx, path, nil, transferLockResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	transferLockResponse.HTTPResponse = resp
+	return transferLockResponse, nil
+}
+
+// DisableDomainTransferLock disables the transfer lock for the domain.
+//
+// See https://developer.dnsimple.com/v2/registrar/#disableDomainTransferLock
+func (s *RegistrarService) DisableDomainTransferLock(ctx context.Context, accountID string, domainName string) (*TransferLockResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/registrar/domains/%v/transfer_lock", accountID, domainName))
+	transferLockResponse := &TransferLockResponse{}
+
+	resp, err := s.client.delete(ctx, path, nil, transferLockResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	transferLockResponse.HTTPResponse = resp
+	return transferLockResponse, nil
+}
@@ -0,0 +1,147 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// TransferLockAuditOptions specifies the optional parameters you can provide
+// to customize the RegistrarService.AuditTransferLocks method.
+type TransferLockAuditOptions struct {
+	// Enable the transfer lock of the unlocked domains.
+	Enforce bool
+
+	// The number of domains audited concurrently. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+}
+
+// DomainTransferLockStatus represents the transfer lock of a domain in a TransferLockAudit.
+type DomainTransferLockStatus struct {
+	Domain string `json:"domain"`
+	// Whether the transfer lock is enabled, after the audit.
+	Locked bool `json:"locked"`
+	// Whether the transfer lock was enabled by the audit.
+	Enforced bool `json:"enforced,omitempty"`
+	// The error getting or enabling the transfer lock, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// TransferLockAudit represents the outcome of RegistrarService.AuditTransferLocks.
+type TransferLockAudit struct {
+	Domains []DomainTransferLockStatus `json:"domains"`
+}
+
+// Unlocked returns the domains whose transfer lock is not enabled,
+// including the ones whose status could not be fetched.
+func (a *TransferLockAudit) Unlocked() []DomainTransferLockStatus {
+	unlocked := []DomainTransferLockStatus{}
+	for _, domain := range a.Domains {
+		if !domain.Locked {
+			unlocked = append(unlocked, domain)
+		}
+	}
+	return unlocked
+}
+
+// Summary returns a one line summary of the audit.
+func (a *TransferLockAudit) Summary() string {
+	var locked, enforced, failed int
+	for _, domain := range a.Domains {
+		if domain.Locked {
+			locked++
+		}
+		if domain.Enforced {
+			enforced++
+		}
+		if domain.Error != "" {
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d domains: %d locked (%d enforced), %d unlocked, %d errors",
+		len(a.Domains), locked, enforced, len(a.Domains)-locked, failed)
+}
+
+// AuditTransferLocks checks the transfer lock of the registered domains of the account,
+// and with options.Enforce enables it on the unlocked ones.
+//
+// The domains are audited concurrently. A domain that can't be checked or locked is recorded
+// with its error in the audit, and doesn't stop the others; an error is returned only if
+// the domains can't be listed.
+func (s *RegistrarService) AuditTransferLocks(ctx context.Context, accountID string, options *TransferLockAuditOptions) (*TransferLockAudit, error) {
+	auditOptions := TransferLockAuditOptions{}
+	if options != nil {
+		auditOptions = *options
+	}
+	auditOptions.Concurrency = concurrency(auditOptions.Concurrency)
+
+	domains, err := s.client.Domains.listAllDomains(ctx, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &TransferLockAudit{Domains: []DomainTransferLockStatus{}}
+	for _, domain := range domains {
+		// Only registered domains have a transfer lock.
+		if domain.State == "registered" {
+			audit.Domains = append(audit.Domains, DomainTransferLockStatus{Domain: domain.Name})
+		}
+	}
+	sort.Slice(audit.Domains, func(i, j int) bool { return audit.Domains[i].Domain < audit.Domains[j].Domain })
+
+	budget := newRateBudget(int(auditOptions.RateLimitReserve))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < auditOptions.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				s.auditTransferLock(ctx, accountID, &audit.Domains[index], auditOptions.Enforce, budget)
+			}
+		}()
+	}
+	for index := range audit.Domains {
+		jobs <- index
+	}
+	close(jobs)
+	wg.Wait()
+
+	return audit, nil
+}
+
+func (s *RegistrarService) auditTransferLock(ctx context.Context, accountID string, status *DomainTransferLockStatus, enforce bool, budget *rateBudget) {
+	if err := budget.wait(ctx); err != nil {
+		status.Error = err.Error()
+		return
+	}
+	lockResponse, err := s.GetDomainTransferLock(ctx, accountID, status.Domain)
+	if err != nil {
+		status.Error = err.Error()
+		return
+	}
+	budget.update(&lockResponse.Response)
+	status.Locked = lockResponse.Data.Enabled
+
+	if status.Locked || !enforce {
+		return
+	}
+
+	if err := budget.wait(ctx); err != nil {
+		status.Error = err.Error()
+		return
+	}
+	lockResponse, err = s.EnableDomainTransferLock(ctx, accountID, status.Domain)
+	if err != nil {
+		status.Error = err.Error()
+		return
+	}
+	budget.update(&lockResponse.Response)
+	status.Locked = lockResponse.Data.Enabled
+	status.Enforced = status.Locked
+}
@@ -0,0 +1,91 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// registerFakeTransferLocks serves the domains of the account 1010 and their transfer locks.
+// The domains starting with "broken" fail to be locked.
+func registerFakeTransferLocks(t *testing.T, locks map[string]bool) *[]string {
+	var mu sync.Mutex
+	enabled := &[]string{}
+
+	mux.HandleFunc("/v2/1010/domains", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"locked.com","state":"registered"},
+			{"id":2,"name":"unlocked.com","state":"registered"},
+			{"id":3,"name":"hosted.com","state":"hosted"},
+			{"id":4,"name":"broken.com","state":"registered"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":4,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/registrar/domains/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		domainName := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/1010/registrar/domains/"), "/transfer_lock")
+		switch r.Method {
+		case "GET":
+		case "POST":
+			if strings.HasPrefix(domainName, "broken") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"message":"The registry does not allow the transfer lock to be changed"}`)
+				return
+			}
+			locks[domainName] = true
+			*enabled = append(*enabled, domainName)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": TransferLock{Enabled: locks[domainName]}})
+	})
+
+	return enabled
+}
+
+func TestRegistrarService_AuditTransferLocks(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	enabled := registerFakeTransferLocks(t, map[string]bool{"locked.com": true})
+
+	audit, err := client.Registrar.AuditTransferLocks(context.Background(), "1010", nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, *enabled)
+	assert.Equal(t, []DomainTransferLockStatus{
+		{Domain: "broken.com"},
+		{Domain: "locked.com", Locked: true},
+		{Domain: "unlocked.com"},
+	}, audit.Domains)
+	assert.Equal(t, []DomainTransferLockStatus{{Domain: "broken.com"}, {Domain: "unlocked.com"}}, audit.Unlocked())
+	assert.Equal(t, "3 domains: 1 locked (0 enforced), 2 unlocked, 0 errors", audit.Summary())
+}
+
+func TestRegistrarService_AuditTransferLocks_Enforce(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	enabled := registerFakeTransferLocks(t, map[string]bool{"locked.com": true})
+
+	audit, err := client.Registrar.AuditTransferLocks(context.Background(), "1010", &TransferLockAuditOptions{Enforce: true, Concurrency: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"unlocked.com"}, *enabled)
+	assert.Len(t, audit.Domains, 3)
+	assert.False(t, audit.Domains[0].Locked)
+	assert.Contains(t, audit.Domains[0].Error, "does not allow the transfer lock")
+	assert.Equal(t, DomainTransferLockStatus{Domain: "locked.com", Locked: true}, audit.Domains[1])
+	assert.Equal(t, DomainTransferLockStatus{Domain: "unlocked.com", Locked: true, Enforced: true}, audit.Domains[2])
+	assert.Equal(t, []DomainTransferLockStatus{audit.Domains[0]}, audit.Unlocked())
+	assert.Equal(t, "3 domains: 2 locked (1 enforced), 1 unlocked, 1 errors", audit.Summary())
+}
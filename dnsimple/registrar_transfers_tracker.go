@@ -0,0 +1,351 @@
+package dnsimple
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/dnsimple/dnsimple-go/dnsimple/internal/statestore"
+)
+
+// The states of a domain transfer.
+const (
+	DomainTransferStateNew          = "new"
+	DomainTransferStateTransferring = "transferring"
+	DomainTransferStateTransferred  = "transferred"
+	DomainTransferStateFailed       = "failed"
+	DomainTransferStateCancelled    = "cancelled"
+)
+
+// domainTransferFinished reports whether a transfer in the state will not change anymore.
+func domainTransferFinished(state string) bool {
+	switch state {
+	case DomainTransferStateTransferred, DomainTransferStateFailed, DomainTransferStateCancelled:
+		return true
+	}
+	return false
+}
+
+// TrackedDomainTransfer represents a domain transfer followed by a DomainTransferTracker.
+type TrackedDomainTransfer struct {
+	Domain            string `json:"domain"`
+	TransferID        int64  `json:"transfer_id"`
+	State             string `json:"state"`
+	StatusDescription string `json:"status_description,omitempty"`
+	// When the tracking started.
+	StartedAt time.Time `json:"started_at"`
+	// When the transfer is cancelled if it didn't finish. Zero for no deadline.
+	Deadline time.Time `json:"deadline"`
+	// Whether the transfer was cancelled because of the deadline.
+	CancelRequested bool `json:"cancel_requested,omitempty"`
+	// When the transfer is polled next.
+	NextPollAt time.Time `json:"next_poll_at"`
+	// The polls since the state last changed, used for the backoff.
+	Polls int `json:"polls"`
+}
+
+// Finished reports whether the transfer is transferred, failed or cancelled.
+func (t *TrackedDomainTransfer) Finished() bool {
+	return domainTransferFinished(t.State)
+}
+
+// DomainTransferEventType represents the kind of a DomainTransferEvent.
+type DomainTransferEventType string
+
+// The kinds of DomainTransferEvent.
+const (
+	// The transfer moved to a state that is not final.
+	DomainTransferEventStateChanged DomainTransferEventType = "state_changed"
+	// The transfer completed.
+	DomainTransferEventTransferred DomainTransferEventType = "transferred"
+	// The transfer failed, the StatusDescription of the event holds the reason.
+	DomainTransferEventFailed DomainTransferEventType = "failed"
+	// The transfer was cancelled.
+	DomainTransferEventCancelled DomainTransferEventType = "cancelled"
+	// The deadline passed and the cancellation of the transfer was requested.
+	DomainTransferEventDeadlineExceeded DomainTransferEventType = "deadline_exceeded"
+	// The transfer could not be polled or cancelled, Err holds the error. The tracker retries later.
+	DomainTransferEventError DomainTransferEventType = "error"
+)
+
+// DomainTransferEvent represents a change of a tracked domain transfer.
+type DomainTransferEvent struct {
+	Type              DomainTransferEventType
+	Domain            string
+	TransferID        int64
+	PreviousState     string
+	State             string
+	StatusDescription string
+	Err               error
+}
+
+// DomainTransferStore persists the transfers followed by a DomainTransferTracker.
+type DomainTransferStore interface {
+	// Load returns every tracked transfer, keyed by domain name.
+	Load(ctx context.Context) (map[string]*TrackedDomainTransfer, error)
+	// Save stores a transfer, replacing any previous transfer of the same domain.
+	Save(ctx context.Context, transfer *TrackedDomainTransfer) error
+}
+
+// DomainTransferTracker follows domain transfers until they are transferred, failed or cancelled.
+//
+// The transfers are polled with GetDomainTransfer, starting every MinInterval and backing off
+// up to MaxInterval while the state doesn't change. Every change is reported to OnEvent.
+// The transfers are saved to the Store, so a tracker created with the same store after
+// a restart resumes the transfers in flight.
+type DomainTransferTracker struct {
+	client    *Client
+	accountID string
+
+	// The store of the transfers. Defaults to an in-memory store.
+	Store DomainTransferStore
+
+	// OnEvent, if set, is called for every event of the tracked transfers.
+	OnEvent func(DomainTransferEvent)
+
+	// The interval between the first polls. Defaults to 1 minute.
+	MinInterval time.Duration
+	// The maximum interval between polls. Defaults to 1 hour.
+	MaxInterval time.Duration
+
+	// CancelAfter, if set, cancels with CancelDomainTransfer the transfers
+	// not finished after the duration.
+	CancelAfter time.Duration
+
+	// now and sleep can be replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewDomainTransferTracker returns a tracker for the domain transfers of an account.
+func NewDomainTransferTracker(client *Client, accountID string) *DomainTransferTracker {
+	return &DomainTransferTracker{
+		client:      client,
+		accountID:   accountID,
+		Store:       &memoryDomainTransferStore{transfers: statestore.NewMemory(trackedDomainTransferKey)},
+		MinInterval: time.Minute,
+		MaxInterval: time.Hour,
+		now:         time.Now,
+		sleep:       sleepContext,
+	}
+}
+
+// TransferDomain transfers a domain with RegistrarService.TransferDomain and tracks the transfer.
+func (t *DomainTransferTracker) TransferDomain(ctx context.Context, domainName string, input *TransferDomainInput) (*TrackedDomainTransfer, error) {
+	transferResponse, err := t.client.Registrar.TransferDomain(ctx, t.accountID, domainName, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Track(ctx, domainName, transferResponse.Data)
+}
+
+// Track starts tracking a transfer, replacing any transfer of the same domain already tracked.
+func (t *DomainTransferTracker) Track(ctx context.Context, domainName string, transfer *DomainTransfer) (*TrackedDomainTransfer, error) {
+	now := t.now()
+	tracked := &TrackedDomainTransfer{
+		Domain:            domainName,
+		TransferID:        transfer.ID,
+		State:             transfer.State,
+		StatusDescription: transfer.StatusDescription,
+		StartedAt:         now,
+		NextPollAt:        now.Add(t.MinInterval),
+	}
+	if t.CancelAfter > 0 {
+		tracked.Deadline = now.Add(t.CancelAfter)
+	}
+
+	if err := t.Store.Save(ctx, tracked); err != nil {
+		return nil, err
+	}
+	return tracked, nil
+}
+
+// Transfers returns the tracked transfers, sorted by domain name.
+func (t *DomainTransferTracker) Transfers(ctx context.Context) ([]TrackedDomainTransfer, error) {
+	transfers, err := t.Store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]TrackedDomainTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		list = append(list, *transfer)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Domain < list[j].Domain })
+	return list, nil
+}
+
+// Poll polls the transfers that are due, and cancels the ones past their deadline.
+// It returns when the next poll is due, or the zero time when every transfer is finished.
+//
+// Errors polling or cancelling a transfer are reported as DomainTransferEventError events
+// and retried on a later poll. Only the errors of the store are returned.
+func (t *DomainTransferTracker) Poll(ctx context.Context) (time.Time, error) {
+	transfers, err := t.Store.Load(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	names := make([]string, 0, len(transfers))
+	for name := range transfers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var next time.Time
+	for _, name := range names {
+		transfer := transfers[name]
+		if transfer.Finished() {
+			continue
+		}
+
+		if !t.now().Before(transfer.NextPollAt) {
+			if err := ctx.Err(); err != nil {
+				return time.Time{}, err
+			}
+			t.poll(ctx, transfer)
+			if err := t.Store.Save(ctx, transfer); err != nil {
+				return time.Time{}, err
+			}
+		}
+
+		if !transfer.Finished() && (next.IsZero() || transfer.NextPollAt.Before(next)) {
+			next = transfer.NextPollAt
+		}
+	}
+	return next, nil
+}
+
+// Run polls the transfers until every transfer is finished or the context is done.
+func (t *DomainTransferTracker) Run(ctx context.Context) error {
+	for {
+		next, err := t.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		if next.IsZero() {
+			return nil
+		}
+
+		if err := t.sleep(ctx, next.Sub(t.now())); err != nil {
+			return err
+		}
+	}
+}
+
+// poll updates a transfer that is due, and schedules its next poll.
+func (t *DomainTransferTracker) poll(ctx context.Context, transfer *TrackedDomainTransfer) {
+	if !transfer.Deadline.IsZero() && !transfer.CancelRequested && !t.now().Before(transfer.Deadline) {
+		_, err := t.client.Registrar.CancelDomainTransfer(ctx, t.accountID, transfer.Domain, transfer.TransferID)
+		if err != nil {
+			t.emit(transfer, DomainTransferEventError, transfer.State, err)
+		} else {
+			transfer.CancelRequested = true
+			t.emit(transfer, DomainTransferEventDeadlineExceeded, transfer.State, nil)
+		}
+	}
+
+	transferResponse, err := t.client.Registrar.GetDomainTransfer(ctx, t.accountID, transfer.Domain, transfer.TransferID)
+	if err != nil {
+		t.emit(transfer, DomainTransferEventError, transfer.State, err)
+		t.schedule(transfer)
+		return
+	}
+
+	previous := transfer.State
+	transfer.State = transferResponse.Data.State
+	transfer.StatusDescription = transferResponse.Data.StatusDescription
+	if transfer.State == previous {
+		t.schedule(transfer)
+		return
+	}
+
+	switch transfer.State {
+	case DomainTransferStateTransferred:
+		t.emit(transfer, DomainTransferEventTransferred, previous, nil)
+	case DomainTransferStateFailed:
+		t.emit(transfer, DomainTransferEventFailed, previous, nil)
+	case DomainTransferStateCancelled:
+		t.emit(transfer, DomainTransferEventCancelled, previous, nil)
+	default:
+		t.emit(transfer, DomainTransferEventStateChanged, previous, nil)
+	}
+	transfer.Polls = 0
+	t.schedule(transfer)
+}
+
+// schedule sets the next poll of a transfer, doubling the interval on every poll
+// without changes up to MaxInterval, and polling at the deadline if it comes first.
+func (t *DomainTransferTracker) schedule(transfer *TrackedDomainTransfer) {
+	interval := t.MinInterval
+	for i := 0; i < transfer.Polls && interval < t.MaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > t.MaxInterval {
+		interval = t.MaxInterval
+	}
+	transfer.Polls++
+
+	now := t.now()
+	transfer.NextPollAt = now.Add(interval)
+	if !transfer.Deadline.IsZero() && !transfer.CancelRequested && transfer.Deadline.Before(transfer.NextPollAt) {
+		transfer.NextPollAt = transfer.Deadline
+		if transfer.NextPollAt.Before(now) {
+			transfer.NextPollAt = now.Add(t.MinInterval)
+		}
+	}
+}
+
+func (t *DomainTransferTracker) emit(transfer *TrackedDomainTransfer, eventType DomainTransferEventType, previous string, err error) {
+	if t.OnEvent == nil {
+		return
+	}
+	t.OnEvent(DomainTransferEvent{
+		Type:              eventType,
+		Domain:            transfer.Domain,
+		TransferID:        transfer.TransferID,
+		PreviousState:     previous,
+		State:             transfer.State,
+		StatusDescription: transfer.StatusDescription,
+		Err:               err,
+	})
+}
+
+func trackedDomainTransferKey(transfer *TrackedDomainTransfer) string {
+	return transfer.Domain
+}
+
+type memoryDomainTransferStore struct {
+	transfers *statestore.Memory[string, TrackedDomainTransfer]
+}
+
+func (s *memoryDomainTransferStore) Load(ctx context.Context) (map[string]*TrackedDomainTransfer, error) {
+	return s.transfers.Load()
+}
+
+func (s *memoryDomainTransferStore) Save(ctx context.Context, transfer *TrackedDomainTransfer) error {
+	return s.transfers.Save(transfer)
+}
+
+// FileDomainTransferStore is a DomainTransferStore that keeps the transfers in a JSON file,
+// rewritten atomically on every save.
+type FileDomainTransferStore struct {
+	file *statestore.File[string, TrackedDomainTransfer]
+}
+
+// NewFileDomainTransferStore returns a store backed by the file at path.
+// The file is created on the first save.
+func NewFileDomainTransferStore(path string) *FileDomainTransferStore {
+	return &FileDomainTransferStore{file: statestore.NewFile(path, trackedDomainTransferKey)}
+}
+
+// Load implements DomainTransferStore.
+func (s *FileDomainTransferStore) Load(ctx context.Context) (map[string]*TrackedDomainTransfer, error) {
+	return s.file.Load()
+}
+
+// Save implements DomainTransferStore.
+func (s *FileDomainTransferStore) Save(ctx context.Context, transfer *TrackedDomainTransfer) error {
+	return s.file.Save(transfer)
+}
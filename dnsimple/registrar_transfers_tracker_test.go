@@ -0,0 +1,197 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTransfer serves the transfer 1 of example.com, moving through states on every poll.
+type fakeTransfer struct {
+	states    []DomainTransfer
+	polls     int
+	failPoll  bool
+	cancelled bool
+}
+
+func (f *fakeTransfer) register(t *testing.T) {
+	mux.HandleFunc("/v2/1010/registrar/domains/example.com/transfers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			f.cancelled = true
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.states[0]})
+			return
+		}
+
+		testMethod(t, r, "GET")
+		if f.failPoll {
+			f.failPoll = false
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"message":"Internal error"}`)
+			return
+		}
+
+		state := f.states[f.polls]
+		if f.polls < len(f.states)-1 {
+			f.polls++
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": state})
+	})
+}
+
+func newTestDomainTransferTracker(now *time.Time) (*DomainTransferTracker, *[]DomainTransferEvent) {
+	events := &[]DomainTransferEvent{}
+	tracker := NewDomainTransferTracker(client, "1010")
+	tracker.now = func() time.Time { return *now }
+	tracker.sleep = func(ctx context.Context, d time.Duration) error {
+		*now = now.Add(d)
+		return nil
+	}
+	tracker.OnEvent = func(event DomainTransferEvent) {
+		*events = append(*events, event)
+	}
+	return tracker, events
+}
+
+func TestDomainTransferTracker_TransferDomain(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/registrar/domains/example.com/transfers", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/transferDomain/success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker, _ := newTestDomainTransferTracker(&now)
+	tracker.CancelAfter = 24 * time.Hour
+
+	tracked, err := tracker.TransferDomain(context.Background(), "example.com", &TransferDomainInput{RegistrantID: 2, AuthCode: "x1y2z3"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tracked.TransferID)
+	assert.Equal(t, DomainTransferStateTransferring, tracked.State)
+	assert.Equal(t, now.Add(time.Minute), tracked.NextPollAt)
+	assert.Equal(t, now.Add(24*time.Hour), tracked.Deadline)
+
+	transfers, err := tracker.Transfers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []TrackedDomainTransfer{*tracked}, transfers)
+}
+
+func TestDomainTransferTracker_Run(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeTransfer{failPoll: true, states: []DomainTransfer{
+		{ID: 1, State: "transferring"},
+		{ID: 1, State: "transferring"},
+		{ID: 1, State: "failed", StatusDescription: "Auth code is invalid"},
+	}}
+	fake.register(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker, events := newTestDomainTransferTracker(&now)
+	_, err := tracker.Track(context.Background(), "example.com", &DomainTransfer{ID: 1, State: "new"})
+	assert.NoError(t, err)
+
+	err = tracker.Run(context.Background())
+
+	assert.NoError(t, err)
+	// error at 1m, transferring at 2m, unchanged at 3m, failed at 5m
+	assert.Equal(t, start.Add(5*time.Minute), now)
+	assert.Len(t, *events, 3)
+	assert.Equal(t, DomainTransferEventError, (*events)[0].Type)
+	assert.Error(t, (*events)[0].Err)
+	assert.Equal(t, DomainTransferEvent{Type: DomainTransferEventStateChanged, Domain: "example.com", TransferID: 1, PreviousState: "new", State: "transferring"}, (*events)[1])
+	assert.Equal(t, DomainTransferEvent{Type: DomainTransferEventFailed, Domain: "example.com", TransferID: 1, PreviousState: "transferring", State: "failed", StatusDescription: "Auth code is invalid"}, (*events)[2])
+	assert.False(t, fake.cancelled)
+
+	next, err := tracker.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, next.IsZero())
+}
+
+func TestDomainTransferTracker_CancelAfter(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	fake := &fakeTransfer{states: []DomainTransfer{
+		{ID: 1, State: "transferring"},
+		{ID: 1, State: "transferring"},
+		{ID: 1, State: "transferring"},
+		{ID: 1, State: "cancelled", StatusDescription: "Canceled by customer"},
+	}}
+	fake.register(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	tracker, events := newTestDomainTransferTracker(&now)
+	tracker.CancelAfter = 3 * time.Minute
+	_, err := tracker.Track(context.Background(), "example.com", &DomainTransfer{ID: 1, State: "transferring"})
+	assert.NoError(t, err)
+
+	err = tracker.Run(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, fake.cancelled)
+	assert.Len(t, *events, 2)
+	assert.Equal(t, DomainTransferEventDeadlineExceeded, (*events)[0].Type)
+	assert.Equal(t, DomainTransferEventCancelled, (*events)[1].Type)
+
+	transfers, err := tracker.Transfers(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, transfers[0].CancelRequested)
+	assert.True(t, transfers[0].Finished())
+}
+
+func TestDomainTransferTracker_Schedule(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker, _ := newTestDomainTransferTracker(&now)
+	tracker.MaxInterval = 5 * time.Minute
+
+	transfer := &TrackedDomainTransfer{}
+	var intervals []time.Duration
+	for i := 0; i < 5; i++ {
+		tracker.schedule(transfer)
+		intervals = append(intervals, transfer.NextPollAt.Sub(now))
+	}
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}, intervals)
+
+	transfer = &TrackedDomainTransfer{Polls: 10, Deadline: now.Add(90 * time.Second)}
+	tracker.schedule(transfer)
+	assert.Equal(t, transfer.Deadline, transfer.NextPollAt)
+}
+
+func TestFileDomainTransferStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transfers.json")
+	store := NewFileDomainTransferStore(path)
+
+	transfers, err := store.Load(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, transfers)
+
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Save(context.Background(), &TrackedDomainTransfer{Domain: "b.com", TransferID: 2, State: "new", StartedAt: startedAt}))
+	assert.NoError(t, store.Save(context.Background(), &TrackedDomainTransfer{Domain: "a.com", TransferID: 1, State: "transferring", StartedAt: startedAt}))
+	assert.NoError(t, store.Save(context.Background(), &TrackedDomainTransfer{Domain: "b.com", TransferID: 2, State: "transferring", StartedAt: startedAt}))
+
+	transfers, err = NewFileDomainTransferStore(path).Load(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 2)
+	assert.Equal(t, "transferring", transfers["b.com"].State)
+	assert.Equal(t, startedAt, transfers["a.com"].StartedAt)
+}
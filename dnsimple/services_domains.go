@@ -69,3 +69,19 @@ func (s *ServicesService) UnapplyService(ctx context.Context, accountID string,
 	serviceResponse.HTTPResponse = resp
 	return serviceResponse, nil
 }
+
+// listAllAppliedServices pages through AppliedServices and returns the services from every page.
+func (s *ServicesService) listAllAppliedServices(ctx context.Context, accountID string, domainIdentifier string) ([]Service, error) {
+	var services []Service
+	for page := 1; ; page++ {
+		servicesResponse, err := s.AppliedServices(ctx, accountID, domainIdentifier, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+
+		services = append(services, servicesResponse.Data...)
+		if servicesResponse.Pagination == nil || page >= servicesResponse.Pagination.TotalPages {
+			return services, nil
+		}
+	}
+}
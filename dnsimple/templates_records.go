@@ -106,3 +106,19 @@ func (s *TemplatesService) DeleteTemplateRecord(ctx context.Context, accountID s
 	templateRecordResponse.HTTPResponse = resp
 	return templateRecordResponse, nil
 }
+
+// listAllTemplateRecords pages through ListTemplateRecords and returns the template records from every page.
+func (s *TemplatesService) listAllTemplateRecords(ctx context.Context, accountID string, templateIdentifier string) ([]TemplateRecord, error) {
+	var records []TemplateRecord
+	for page := 1; ; page++ {
+		recordsResponse, err := s.ListTemplateRecords(ctx, accountID, templateIdentifier, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, recordsResponse.Data...)
+		if recordsResponse.Pagination == nil || page >= recordsResponse.Pagination.TotalPages {
+			return records, nil
+		}
+	}
+}
@@ -11,6 +11,10 @@ import (
 // See https://developer.dnsimple.com/v2/tlds/
 type TldsService struct {
 	client *Client
+
+	// extendedAttributes caches the extended attributes by TLD,
+	// see cachedExtendedAttributes.
+	extendedAttributes extendedAttributesCache
 }
 
 // Tld represents a TLD in DNSimple.
@@ -86,6 +90,22 @@ func (s *TldsService) ListTlds(ctx context.Context, options *ListOptions) (*Tlds
 	return tldsResponse, nil
 }
 
+// listAllTlds pages through ListTlds and returns the TLDs from every page.
+func (s *TldsService) listAllTlds(ctx context.Context) ([]Tld, error) {
+	var tlds []Tld
+	for page := 1; ; page++ {
+		tldsResponse, err := s.ListTlds(ctx, &ListOptions{Page: Int(page)})
+		if err != nil {
+			return nil, err
+		}
+
+		tlds = append(tlds, tldsResponse.Data...)
+		if tldsResponse.Pagination == nil || page >= tldsResponse.Pagination.TotalPages {
+			return tlds, nil
+		}
+	}
+}
+
 // GetTld fetches a TLD.
 //
 // See https://developer.dnsimple.com/v2/tlds/#get
@@ -0,0 +1,216 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// extendedAttributesCacheTTL is how long the extended attributes of a TLD are cached.
+// They change very rarely, but a long running process should still pick up the changes.
+const extendedAttributesCacheTTL = time.Hour
+
+// ExtendedAttributeProblem represents an extended attribute value that is not accepted by a TLD.
+type ExtendedAttributeProblem struct {
+	// The name of the extended attribute.
+	Name    string
+	Message string
+}
+
+// ExtendedAttributesError is returned by TldsService.ValidateExtendedAttributes
+// when the extended attributes are not valid for the TLD.
+type ExtendedAttributesError struct {
+	Tld      string
+	Problems []ExtendedAttributeProblem
+}
+
+// Error implements the error interface.
+func (e *ExtendedAttributesError) Error() string {
+	problems := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		problems = append(problems, fmt.Sprintf("%v: %v", problem.Name, problem.Message))
+	}
+	return fmt.Sprintf("invalid extended attributes for .%v: %v", e.Tld, strings.Join(problems, "; "))
+}
+
+// CheckExtendedAttributes checks the values of the extended attributes against the attributes of a TLD,
+// as returned by TldsService.GetTldExtendedAttributes:
+//
+//   - the required attributes must have a value
+//   - the attributes with options must have one of the option values
+//   - the values of attributes the TLD doesn't define are rejected
+//
+// It returns the problems found, or nil when the values are valid.
+func CheckExtendedAttributes(attributes []TldExtendedAttribute, values map[string]string) []ExtendedAttributeProblem {
+	var problems []ExtendedAttributeProblem
+	known := make(map[string]bool, len(attributes))
+	for _, attribute := range attributes {
+		known[attribute.Name] = true
+
+		value := values[attribute.Name]
+		if value == "" {
+			if attribute.Required {
+				problems = append(problems, ExtendedAttributeProblem{Name: attribute.Name, Message: "is required"})
+			}
+			continue
+		}
+		if len(attribute.Options) == 0 {
+			continue
+		}
+
+		allowed := make([]string, 0, len(attribute.Options))
+		valid := false
+		for _, option := range attribute.Options {
+			allowed = append(allowed, option.Value)
+			if option.Value == value {
+				valid = true
+			}
+		}
+		if !valid {
+			problems = append(problems, ExtendedAttributeProblem{Name: attribute.Name, Message: fmt.Sprintf("%q is not one of %v", value, strings.Join(allowed, ", "))})
+		}
+	}
+
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		problems = append(problems, ExtendedAttributeProblem{Name: name, Message: "is not supported by the TLD"})
+	}
+
+	return problems
+}
+
+// ValidateExtendedAttributes checks the extended attributes to use in RegisterDomainInput
+// or TransferDomainInput against the extended attributes of a TLD, see CheckExtendedAttributes.
+//
+// The extended attributes of the TLD are fetched with GetTldExtendedAttributes and cached.
+// It returns an *ExtendedAttributesError when the values are not valid.
+func (s *TldsService) ValidateExtendedAttributes(ctx context.Context, tld string, values map[string]string) error {
+	tld = normalizeTld(tld)
+	attributes, err := s.cachedExtendedAttributes(ctx, tld)
+	if err != nil {
+		return err
+	}
+
+	if problems := CheckExtendedAttributes(attributes, values); len(problems) > 0 {
+		return &ExtendedAttributesError{Tld: tld, Problems: problems}
+	}
+	return nil
+}
+
+// ExtendedAttributesSchema represents a JSON Schema describing the extended attributes of a TLD.
+// It validates the same rules as CheckExtendedAttributes.
+type ExtendedAttributesSchema struct {
+	Schema               string                                     `json:"$schema"`
+	Title                string                                     `json:"title"`
+	Type                 string                                     `json:"type"`
+	Properties           map[string]ExtendedAttributeSchemaProperty `json:"properties"`
+	Required             []string                                   `json:"required,omitempty"`
+	AdditionalProperties bool                                       `json:"additionalProperties"`
+}
+
+// ExtendedAttributeSchemaProperty represents the JSON Schema of a single extended attribute.
+type ExtendedAttributeSchemaProperty struct {
+	Type      string                          `json:"type"`
+	Title     string                          `json:"title,omitempty"`
+	MinLength int                             `json:"minLength,omitempty"`
+	OneOf     []ExtendedAttributeSchemaOption `json:"oneOf,omitempty"`
+}
+
+// ExtendedAttributeSchemaOption represents one of the values allowed for an extended attribute.
+type ExtendedAttributeSchemaOption struct {
+	Const       string `json:"const"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NewExtendedAttributesSchema builds the JSON Schema for the extended attributes of a TLD.
+func NewExtendedAttributesSchema(tld string, attributes []TldExtendedAttribute) *ExtendedAttributesSchema {
+	schema := &ExtendedAttributesSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      fmt.Sprintf("Extended attributes for .%v", tld),
+		Type:       "object",
+		Properties: make(map[string]ExtendedAttributeSchemaProperty, len(attributes)),
+	}
+
+	for _, attribute := range attributes {
+		property := ExtendedAttributeSchemaProperty{Type: "string", Title: attribute.Description}
+		for _, option := range attribute.Options {
+			property.OneOf = append(property.OneOf, ExtendedAttributeSchemaOption{Const: option.Value, Title: option.Title, Description: option.Description})
+		}
+		if attribute.Required {
+			// An empty value is treated as missing.
+			property.MinLength = 1
+			schema.Required = append(schema.Required, attribute.Name)
+		}
+		schema.Properties[attribute.Name] = property
+	}
+
+	return schema
+}
+
+// GetExtendedAttributesSchema builds the JSON Schema for the extended attributes of a TLD,
+// see NewExtendedAttributesSchema.
+//
+// The extended attributes of the TLD are fetched with GetTldExtendedAttributes and cached.
+func (s *TldsService) GetExtendedAttributesSchema(ctx context.Context, tld string) (*ExtendedAttributesSchema, error) {
+	tld = normalizeTld(tld)
+	attributes, err := s.cachedExtendedAttributes(ctx, tld)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewExtendedAttributesSchema(tld, attributes), nil
+}
+
+// extendedAttributesCache holds the extended attributes fetched by cachedExtendedAttributes.
+// The zero value is an empty cache.
+type extendedAttributesCache struct {
+	mu      sync.Mutex
+	entries map[string]extendedAttributesCacheEntry
+}
+
+type extendedAttributesCacheEntry struct {
+	attributes []TldExtendedAttribute
+	expiresAt  time.Time
+}
+
+// cachedExtendedAttributes returns the extended attributes of a TLD,
+// calling GetTldExtendedAttributes when they are not cached or the cache expired.
+// Errors are not cached.
+func (s *TldsService) cachedExtendedAttributes(ctx context.Context, tld string) ([]TldExtendedAttribute, error) {
+	cache := &s.extendedAttributes
+
+	cache.mu.Lock()
+	entry, ok := cache.entries[tld]
+	cache.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.attributes, nil
+	}
+
+	attributesResponse, err := s.GetTldExtendedAttributes(ctx, tld)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]extendedAttributesCacheEntry)
+	}
+	cache.entries[tld] = extendedAttributesCacheEntry{attributes: attributesResponse.Data, expiresAt: time.Now().Add(extendedAttributesCacheTTL)}
+	return attributesResponse.Data, nil
+}
+
+// normalizeTld returns the TLD in lower case and without dots, such as "co.uk" for ".CO.UK".
+func normalizeTld(tld string) string {
+	return strings.ToLower(strings.Trim(tld, "."))
+}
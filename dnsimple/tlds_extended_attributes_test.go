@@ -0,0 +1,111 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testExtendedAttributes = []TldExtendedAttribute{
+	{Name: "x_legal_type", Description: "Legal type", Required: true, Options: []TldExtendedAttributeOption{
+		{Title: "Individual", Value: "IND", Description: "An individual"},
+		{Title: "Company", Value: "LTD"},
+	}},
+	{Name: "x_trading_name", Description: "Trading name"},
+}
+
+func TestCheckExtendedAttributes(t *testing.T) {
+	assert.Nil(t, CheckExtendedAttributes(testExtendedAttributes, map[string]string{"x_legal_type": "IND"}))
+	assert.Nil(t, CheckExtendedAttributes(testExtendedAttributes, map[string]string{"x_legal_type": "LTD", "x_trading_name": "Example"}))
+	assert.Nil(t, CheckExtendedAttributes(nil, nil))
+
+	problems := CheckExtendedAttributes(testExtendedAttributes, map[string]string{"x_legal_type": "", "z_unknown": "1", "a_unknown": "2"})
+	assert.Equal(t, []ExtendedAttributeProblem{
+		{Name: "x_legal_type", Message: "is required"},
+		{Name: "a_unknown", Message: "is not supported by the TLD"},
+		{Name: "z_unknown", Message: "is not supported by the TLD"},
+	}, problems)
+
+	problems = CheckExtendedAttributes(testExtendedAttributes, map[string]string{"x_legal_type": "ind"})
+	assert.Equal(t, []ExtendedAttributeProblem{{Name: "x_legal_type", Message: `"ind" is not one of IND, LTD`}}, problems)
+}
+
+func TestTldsService_ValidateExtendedAttributes(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	requests := 0
+	mux.HandleFunc("/v2/tlds/uk/extended_attributes", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/getTldExtendedAttributes/success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		requests++
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	err := client.Tlds.ValidateExtendedAttributes(context.Background(), "uk", map[string]string{"uk_legal_type": "IND", "registered_for": "Example"})
+	assert.NoError(t, err)
+
+	err = client.Tlds.ValidateExtendedAttributes(context.Background(), ".UK", map[string]string{"uk_legal_type": "XYZ", "registered_for": "Example", "foo": "bar"})
+	var invalid *ExtendedAttributesError
+	assert.True(t, errors.As(err, &invalid))
+	assert.Equal(t, "uk", invalid.Tld)
+	assert.Len(t, invalid.Problems, 2)
+	assert.Equal(t, "uk_legal_type", invalid.Problems[0].Name)
+	assert.Equal(t, "foo", invalid.Problems[1].Name)
+
+	_, err = client.Tlds.GetExtendedAttributesSchema(context.Background(), "uk")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, requests)
+}
+
+func TestTldsService_ValidateExtendedAttributes_Error(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/tlds/uk/extended_attributes", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/notfound-domain.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	err := client.Tlds.ValidateExtendedAttributes(context.Background(), "uk", nil)
+	assert.True(t, isNotFound(err))
+	assert.Empty(t, client.Tlds.extendedAttributes.entries)
+}
+
+func TestNewExtendedAttributesSchema(t *testing.T) {
+	schema := NewExtendedAttributesSchema("x", testExtendedAttributes)
+
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Extended attributes for .x",
+		"type": "object",
+		"properties": {
+			"x_legal_type": {
+				"type": "string",
+				"title": "Legal type",
+				"minLength": 1,
+				"oneOf": [
+					{"const": "IND", "title": "Individual", "description": "An individual"},
+					{"const": "LTD", "title": "Company"}
+				]
+			},
+			"x_trading_name": {"type": "string", "title": "Trading name"}
+		},
+		"required": ["x_legal_type"],
+		"additionalProperties": false
+	}`, string(data))
+}
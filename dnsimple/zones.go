@@ -2,7 +2,9 @@ package dnsimple
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // ZonesService handles communication with the zone related
@@ -19,6 +21,7 @@ type Zone struct {
 	AccountID int64  `json:"account_id,omitempty"`
 	Name      string `json:"name,omitempty"`
 	Reverse   bool   `json:"reverse,omitempty"`
+	Active    bool   `json:"active"`
 	CreatedAt string `json:"created_at,omitempty"`
 	UpdatedAt string `json:"updated_at,omitempty"`
 }
@@ -107,3 +110,125 @@ func (s *ZonesService) GetZoneFile(ctx context.Context, accountID string, zoneNa
 	zoneFileResponse.HTTPResponse = resp
 	return zoneFileResponse, nil
 }
+
+// ActivateDns activates DNS services for a zone.
+//
+// See https://developer.dnsimple.com/v2/zones/#activateZoneService
+func (s *ZonesService) ActivateDns(ctx context.Context, accountID string, zoneName string) (*ZoneResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/zones/%v/activation", accountID, zoneName))
+	zoneResponse := &ZoneResponse{}
+
+	resp, err := s.client.put(ctx, path, nil, zoneResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneResponse.HTTPResponse = resp
+	return zoneResponse, nil
+}
+
+// DeactivateDns deactivates DNS services for a zone.
+//
+// See https://developer.dnsimple.com/v2/zones/#deactivateZoneService
+func (s *ZonesService) DeactivateDns(ctx context.Context, accountID string, zoneName string) (*ZoneResponse, error) {
+	path := versioned(fmt.Sprintf("/%v/zones/%v/activation", accountID, zoneName))
+	zoneResponse := &ZoneResponse{}
+
+	resp, err := s.client.delete(ctx, path, nil, zoneResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneResponse.HTTPResponse = resp
+	return zoneResponse, nil
+}
+
+// ZoneNsRecordsInput represents the name servers to use for the NS records of a zone.
+// At least one name server name or name server set must be provided.
+type ZoneNsRecordsInput struct {
+	// The host names of the name servers.
+	NsNames []string `json:"ns_names,omitempty"`
+	// The IDs of the name server sets to use.
+	NsSetIDs []int64 `json:"ns_set_ids,omitempty"`
+}
+
+// ZoneNsRecordsResponse represents a response from an API method that returns the NS records of a zone.
+type ZoneNsRecordsResponse struct {
+	Response
+	Data []ZoneRecord `json:"data"`
+}
+
+// UpdateNsRecords updates the NS records at the apex of a zone.
+//
+// The name server names are validated before the request is sent.
+//
+// See https://developer.dnsimple.com/v2/zones/#updateZoneNsRecords
+func (s *ZonesService) UpdateNsRecords(ctx context.Context, accountID string, zoneName string, input *ZoneNsRecordsInput) (*ZoneNsRecordsResponse, error) {
+	if input == nil || (len(input.NsNames) == 0 && len(input.NsSetIDs) == 0) {
+		return nil, errors.New("at least one name server or name server set is required")
+	}
+	for _, name := range input.NsNames {
+		if err := validateHostname(name); err != nil {
+			return nil, err
+		}
+	}
+
+	path := versioned(fmt.Sprintf("/%v/zones/%v/ns_records", accountID, zoneName))
+	nsRecordsResponse := &ZoneNsRecordsResponse{}
+
+	resp, err := s.client.put(ctx, path, input, nsRecordsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	nsRecordsResponse.HTTPResponse = resp
+	return nsRecordsResponse, nil
+}
+
+// validateHostname checks that name is a fully qualified host name (RFC 1123),
+// with an optional trailing dot.
+func validateHostname(name string) error {
+	hostname := strings.TrimSuffix(name, ".")
+	if hostname == "" || len(hostname) > 253 {
+		return fmt.Errorf("invalid host name %q", name)
+	}
+
+	labels := strings.Split(hostname, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("invalid host name %q: must be fully qualified", name)
+	}
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("invalid host name %q", name)
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("invalid host name %q", name)
+			}
+		}
+	}
+	return nil
+}
+
+// listAllZones pages through ListZones and returns the zones from every page.
+func (s *ZonesService) listAllZones(ctx context.Context, accountID string, options *ZoneListOptions) ([]Zone, error) {
+	pageOptions := ZoneListOptions{}
+	if options != nil {
+		pageOptions = *options
+	}
+
+	var zones []Zone
+	for page := 1; ; page++ {
+		pageOptions.Page = Int(page)
+
+		zonesResponse, err := s.ListZones(ctx, accountID, &pageOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		zones = append(zones, zonesResponse.Data...)
+		if zonesResponse.Pagination == nil || page >= zonesResponse.Pagination.TotalPages {
+			return zones, nil
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// ZoneRRset represents the records sharing the same name and type in a zone.
+type ZoneRRset struct {
+	// The name of the records, relative to the zone apex.
+	Name    string       `json:"name"`
+	Type    string       `json:"type"`
+	Records []ZoneRecord `json:"records"`
+}
+
+// ZoneRRsetChange represents an RRset that exists in both zones with different records.
+type ZoneRRsetChange struct {
+	Name string    `json:"name"`
+	Type string    `json:"type"`
+	From ZoneRRset `json:"from"`
+	To   ZoneRRset `json:"to"`
+}
+
+// ZoneDiff represents the differences between two sets of zone records.
+type ZoneDiff struct {
+	FromZone string            `json:"from_zone"`
+	ToZone   string            `json:"to_zone"`
+	Added    []ZoneRRset       `json:"added"`
+	Removed  []ZoneRRset       `json:"removed"`
+	Changed  []ZoneRRsetChange `json:"changed"`
+
+	// the options the records were compared with, to match the records of the changed RRsets
+	options ZoneDiffOptions
+}
+
+// ZoneDiffOptions specifies the optional parameters you can provide
+// to customize the zone diff.
+type ZoneDiffOptions struct {
+	// Set to true to ignore system records, such as the SOA and apex NS records.
+	IgnoreSystemRecords bool
+
+	// Set to true to consider records with different TTLs as equal.
+	IgnoreTTL bool
+}
+
+// Empty reports whether the two zones are equivalent.
+func (d *ZoneDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffZones fetches the records of two zones in the account and compares them.
+func (s *ZonesService) DiffZones(ctx context.Context, accountID string, fromZone string, toZone string, options *ZoneDiffOptions) (*ZoneDiff, error) {
+	from, err := s.listAllRecords(ctx, accountID, fromZone, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.listAllRecords(ctx, accountID, toZone, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return DiffZoneRecords(fromZone, from, toZone, to, options), nil
+}
+
+// DiffZoneRecords compares two sets of zone records, such as the records of two zones
+// or two snapshots of the same zone.
+//
+// Record names are normalized relative to each zone apex: names are compared case-insensitively,
+// and fully qualified names (with a trailing dot) within the zone are made relative.
+// Record content is compared in canonical form: host names are compared case-insensitively
+// and without the trailing dot, names within the zone are compared relative to the apex,
+// IP addresses are compared by value and TXT content is compared without quoting.
+func DiffZoneRecords(fromZone string, from []ZoneRecord, toZone string, to []ZoneRecord, options *ZoneDiffOptions) *ZoneDiff {
+	if options == nil {
+		options = &ZoneDiffOptions{}
+	}
+
+	fromSets := groupZoneRRsets(fromZone, from, options)
+	toSets := groupZoneRRsets(toZone, to, options)
+
+	diff := &ZoneDiff{
+		FromZone: normalizeName(fromZone),
+		ToZone:   normalizeName(toZone),
+		Added:    []ZoneRRset{},
+		Removed:  []ZoneRRset{},
+		Changed:  []ZoneRRsetChange{},
+		options:  *options,
+	}
+
+	for key, fromSet := range fromSets {
+		toSet, ok := toSets[key]
+		if !ok {
+			diff.Removed = append(diff.Removed, fromSet.rrset)
+			continue
+		}
+		if !fromSet.equal(toSet) {
+			diff.Changed = append(diff.Changed, ZoneRRsetChange{Name: fromSet.rrset.Name, Type: fromSet.rrset.Type, From: fromSet.rrset, To: toSet.rrset})
+		}
+	}
+	for key, toSet := range toSets {
+		if _, ok := fromSets[key]; !ok {
+			diff.Added = append(diff.Added, toSet.rrset)
+		}
+	}
+
+	sortRRsets := func(sets []ZoneRRset) {
+		sort.Slice(sets, func(i, j int) bool { return rrsetLess(sets[i].Name, sets[i].Type, sets[j].Name, sets[j].Type) })
+	}
+	sortRRsets(diff.Added)
+	sortRRsets(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		return rrsetLess(diff.Changed[i].Name, diff.Changed[i].Type, diff.Changed[j].Name, diff.Changed[j].Type)
+	})
+	return diff
+}
+
+func rrsetLess(nameA, typeA, nameB, typeB string) bool {
+	if nameA != nameB {
+		return nameA < nameB
+	}
+	return typeA < typeB
+}
+
+// Unified returns the diff in a unified-text format, with one line per record.
+// Lines removed from the first zone are prefixed with "-", lines added in the second zone with "+",
+// and unchanged records of a changed RRset with a space.
+// The records of a changed RRset are matched in canonical form, as in DiffZoneRecords,
+// and the unchanged ones are printed as in the first zone.
+func (d *ZoneDiff) Unified() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %v\n", d.FromZone)
+	fmt.Fprintf(&b, "+++ %v\n", d.ToZone)
+
+	type hunk struct {
+		name, recordType string
+		lines            []string
+	}
+	var hunks []hunk
+
+	for _, rrset := range d.Removed {
+		h := hunk{name: rrset.Name, recordType: rrset.Type}
+		for _, record := range rrset.Records {
+			h.lines = append(h.lines, "-"+zoneRecordLine(rrset.Name, record))
+		}
+		hunks = append(hunks, h)
+	}
+	for _, rrset := range d.Added {
+		h := hunk{name: rrset.Name, recordType: rrset.Type}
+		for _, record := range rrset.Records {
+			h.lines = append(h.lines, "+"+zoneRecordLine(rrset.Name, record))
+		}
+		hunks = append(hunks, h)
+	}
+	for _, change := range d.Changed {
+		h := hunk{name: change.Name, recordType: change.Type}
+		// the number of records of each canonical form in the second zone not matched yet
+		unmatched := map[string]int{}
+		for _, record := range change.To.Records {
+			unmatched[canonicalZoneRecord(d.ToZone, record, &d.options)]++
+		}
+		for _, record := range change.From.Records {
+			line := zoneRecordLine(change.Name, record)
+			if canonical := canonicalZoneRecord(d.FromZone, record, &d.options); unmatched[canonical] > 0 {
+				unmatched[canonical]--
+				h.lines = append(h.lines, " "+line)
+			} else {
+				h.lines = append(h.lines, "-"+line)
+			}
+		}
+		// the records of the second zone left unmatched are the added ones
+		matched := map[string]int{}
+		for _, record := range change.From.Records {
+			matched[canonicalZoneRecord(d.FromZone, record, &d.options)]++
+		}
+		for _, record := range change.To.Records {
+			canonical := canonicalZoneRecord(d.ToZone, record, &d.options)
+			if matched[canonical] > 0 {
+				matched[canonical]--
+				continue
+			}
+			h.lines = append(h.lines, "+"+zoneRecordLine(change.Name, record))
+		}
+		hunks = append(hunks, h)
+	}
+
+	sort.SliceStable(hunks, func(i, j int) bool {
+		return rrsetLess(hunks[i].name, hunks[i].recordType, hunks[j].name, hunks[j].recordType)
+	})
+	for _, h := range hunks {
+		fmt.Fprintf(&b, "@@ %v %v @@\n", displayName(h.name), h.recordType)
+		for _, line := range h.lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+func zoneRecordLine(name string, record ZoneRecord) string {
+	line := fmt.Sprintf("%v %d IN %v", displayName(name), record.TTL, record.Type)
+	if record.Priority != 0 {
+		line += fmt.Sprintf(" %d", record.Priority)
+	}
+	line += " " + record.Content
+	if len(record.Regions) > 0 && !(len(record.Regions) == 1 && record.Regions[0] == "global") {
+		line += fmt.Sprintf(" ; regions=%v", strings.Join(record.Regions, ","))
+	}
+	return line
+}
+
+type zoneRRsetEntry struct {
+	rrset ZoneRRset
+	// the canonical form of each record
+	canonical []string
+}
+
+func (e *zoneRRsetEntry) equal(other *zoneRRsetEntry) bool {
+	if len(e.canonical) != len(other.canonical) {
+		return false
+	}
+	for i := range e.canonical {
+		if e.canonical[i] != other.canonical[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func groupZoneRRsets(zoneName string, records []ZoneRecord, options *ZoneDiffOptions) map[string]*zoneRRsetEntry {
+	sets := map[string]*zoneRRsetEntry{}
+	for _, record := range records {
+		if options.IgnoreSystemRecords && record.SystemRecord {
+			continue
+		}
+
+		name := diffRecordName(record.Name, zoneName)
+		recordType := strings.ToUpper(record.Type)
+		key := name + " " + recordType
+
+		entry, ok := sets[key]
+		if !ok {
+			entry = &zoneRRsetEntry{rrset: ZoneRRset{Name: name, Type: recordType}}
+			sets[key] = entry
+		}
+		entry.rrset.Records = append(entry.rrset.Records, record)
+		entry.canonical = append(entry.canonical, canonicalZoneRecord(zoneName, record, options))
+	}
+
+	for _, entry := range sets {
+		sort.Strings(entry.canonical)
+		sort.SliceStable(entry.rrset.Records, func(i, j int) bool {
+			return entry.rrset.Records[i].Content < entry.rrset.Records[j].Content
+		})
+	}
+	return sets
+}
+
+// diffRecordName normalizes a record name relative to the zone apex.
+// Fully qualified names outside the zone are lower-cased, without the trailing dot.
+func diffRecordName(name string, zoneName string) string {
+	if name == "@" {
+		return ""
+	}
+	if strings.HasSuffix(name, ".") {
+		if relative, ok := relativeName(name, zoneName); ok {
+			return relative
+		}
+	}
+	return normalizeName(name)
+}
+
+func canonicalZoneRecord(zoneName string, record ZoneRecord, options *ZoneDiffOptions) string {
+	regions := append([]string(nil), record.Regions...)
+	if len(regions) == 1 && regions[0] == "global" {
+		regions = nil
+	}
+	sort.Strings(regions)
+
+	ttl := record.TTL
+	if options.IgnoreTTL {
+		ttl = 0
+	}
+
+	return fmt.Sprintf("%d|%d|%v|%v", ttl, record.Priority, strings.Join(regions, ","), canonicalContent(zoneName, strings.ToUpper(record.Type), record.Content))
+}
+
+func canonicalContent(zoneName string, recordType string, content string) string {
+	switch recordType {
+	case "A", "AAAA":
+		if ip := net.ParseIP(strings.TrimSpace(content)); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "ALIAS", "MX", "NS", "PTR":
+		return canonicalTarget(zoneName, content)
+	case "SRV":
+		fields := strings.Fields(content)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = canonicalTarget(zoneName, fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case "TXT", "SPF":
+		return unquoteTXT(content)
+	}
+	return strings.TrimSpace(content)
+}
+
+// canonicalTarget returns a host name in canonical form. Names within the zone are
+// returned relative to the apex, so equivalent targets in two different zones compare equal.
+func canonicalTarget(zoneName string, target string) string {
+	target = normalizeName(strings.TrimSpace(target))
+	if relative, ok := relativeName(target, zoneName); ok {
+		return relative + "@"
+	}
+	return target
+}
+
+// unquoteTXT returns the content of a TXT record without the quoting.
+// Content made of several quoted strings is concatenated, as done by resolvers.
+func unquoteTXT(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, `"`) {
+		return content
+	}
+
+	var b strings.Builder
+	inQuotes := false
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			b.WriteByte(content[i])
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffZoneRecords_Equivalent(t *testing.T) {
+	from := []ZoneRecord{
+		{Name: "", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "www", Type: "CNAME", Content: "staging.example.com", TTL: 3600},
+		{Name: "v6", Type: "AAAA", Content: "2001:0db8:0000:0000:0000:0000:0000:0001", TTL: 3600},
+		{Name: "", Type: "TXT", Content: `"v=spf1 " "include:example.net -all"`, TTL: 3600},
+		{Name: "", Type: "MX", Content: "MAIL.staging.example.com.", Priority: 10, TTL: 3600},
+		{Name: "_sip._tcp", Type: "SRV", Content: "10 5060 sip.staging.example.com", Priority: 10, TTL: 3600},
+	}
+	to := []ZoneRecord{
+		{Name: "@", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{Name: "WWW.example.com.", Type: "CNAME", Content: "example.com.", TTL: 3600},
+		{Name: "v6", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+		{Name: "", Type: "TXT", Content: "v=spf1 include:example.net -all", TTL: 3600},
+		{Name: "", Type: "MX", Content: "mail.example.com", Priority: 10, TTL: 3600},
+		{Name: "_sip._tcp", Type: "SRV", Content: "10 5060 sip.example.com.", Priority: 10, TTL: 3600},
+	}
+
+	diff := DiffZoneRecords("staging.example.com", from, "example.com", to, nil)
+
+	assert.True(t, diff.Empty(), diff.Unified())
+}
+
+func TestDiffZoneRecords(t *testing.T) {
+	from := []ZoneRecord{
+		{ID: 1, Name: "", Type: "SOA", Content: "ns1.dnsimple.com admin.dnsimple.com 1 86400 7200 604800 300", TTL: 3600, SystemRecord: true},
+		{ID: 2, Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: 3, Name: "www", Type: "A", Content: "192.0.2.2", TTL: 3600},
+		{ID: 4, Name: "old", Type: "CNAME", Content: "www.example.com", TTL: 3600},
+	}
+	to := []ZoneRecord{
+		{ID: 11, Name: "", Type: "SOA", Content: "ns1.dnsimple.com admin.dnsimple.com 2 86400 7200 604800 300", TTL: 3600, SystemRecord: true},
+		{ID: 12, Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600},
+		{ID: 13, Name: "www", Type: "A", Content: "192.0.2.3", TTL: 3600},
+		{ID: 14, Name: "new", Type: "TXT", Content: "hello", TTL: 300},
+	}
+
+	diff := DiffZoneRecords("example.com", from, "example.com", to, &ZoneDiffOptions{IgnoreSystemRecords: true})
+
+	assert.False(t, diff.Empty())
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "new", diff.Added[0].Name)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "old", diff.Removed[0].Name)
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, "www", diff.Changed[0].Name)
+	assert.Equal(t, "A", diff.Changed[0].Type)
+
+	want := `--- example.com
++++ example.com
+@@ new TXT @@
++new 300 IN TXT hello
+@@ old CNAME @@
+-old 3600 IN CNAME www.example.com
+@@ www A @@
+ www 3600 IN A 192.0.2.1
+-www 3600 IN A 192.0.2.2
++www 3600 IN A 192.0.2.3
+`
+	assert.Equal(t, want, diff.Unified())
+}
+
+func TestDiffZoneRecords_UnifiedEquivalentRecords(t *testing.T) {
+	from := []ZoneRecord{
+		{Name: "", Type: "TXT", Content: `"v=spf1 -all"`, TTL: 3600},
+		{Name: "", Type: "TXT", Content: "google-site-verification=abc", TTL: 3600},
+		{Name: "v6", Type: "AAAA", Content: "2001:0db8:0000:0000:0000:0000:0000:0001", TTL: 3600},
+	}
+	to := []ZoneRecord{
+		{Name: "@", Type: "TXT", Content: "v=spf1 -all", TTL: 3600},
+		{Name: "", Type: "TXT", Content: "google-site-verification=xyz", TTL: 3600},
+		{Name: "v6", Type: "AAAA", Content: "2001:db8::1", TTL: 3600},
+		{Name: "v6", Type: "AAAA", Content: "2001:db8::2", TTL: 3600},
+	}
+
+	diff := DiffZoneRecords("example.com", from, "example.com", to, nil)
+
+	want := `--- example.com
++++ example.com
+@@ @ TXT @@
+ @ 3600 IN TXT "v=spf1 -all"
+-@ 3600 IN TXT google-site-verification=abc
++@ 3600 IN TXT google-site-verification=xyz
+@@ v6 AAAA @@
+ v6 3600 IN AAAA 2001:0db8:0000:0000:0000:0000:0000:0001
++v6 3600 IN AAAA 2001:db8::2
+`
+	assert.Equal(t, want, diff.Unified())
+}
+
+func TestDiffZoneRecords_NamesOutsideZone(t *testing.T) {
+	from := []ZoneRecord{{Name: "Other.NET.", Type: "A", Content: "192.0.2.1", TTL: 3600}}
+	to := []ZoneRecord{{Name: "other.net", Type: "A", Content: "192.0.2.1", TTL: 3600}}
+
+	diff := DiffZoneRecords("example.com", from, "example.com", nil, nil)
+	assert.Equal(t, "other.net", diff.Removed[0].Name)
+	assert.True(t, DiffZoneRecords("example.com", from, "example.com", to, nil).Empty())
+}
+
+func TestDiffZoneRecords_TTLAndRegions(t *testing.T) {
+	from := []ZoneRecord{{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600, Regions: []string{"global"}}}
+	to := []ZoneRecord{{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 60}}
+
+	assert.False(t, DiffZoneRecords("example.com", from, "example.com", to, nil).Empty())
+	assert.True(t, DiffZoneRecords("example.com", from, "example.com", to, &ZoneDiffOptions{IgnoreTTL: true}).Empty())
+
+	to = []ZoneRecord{{Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600, Regions: []string{"IAD", "SV1"}}}
+	diff := DiffZoneRecords("example.com", from, "example.com", to, nil)
+	assert.Len(t, diff.Changed, 1)
+	assert.Contains(t, diff.Unified(), "+www 3600 IN A 192.0.2.1 ; regions=IAD,SV1\n")
+}
+
+func TestZoneDiff_JSON(t *testing.T) {
+	from := []ZoneRecord{{ID: 1, Name: "www", Type: "A", Content: "192.0.2.1", TTL: 3600}}
+
+	diff := DiffZoneRecords("example.com", from, "example.com", nil, nil)
+	data, err := json.Marshal(diff)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"from_zone": "example.com",
+		"to_zone": "example.com",
+		"added": [],
+		"removed": [{"name": "www", "type": "A", "records": [{"id": 1, "name": "www", "type": "A", "content": "192.0.2.1", "ttl": 3600}]}],
+		"changed": []
+	}`, string(data))
+}
+
+func TestUnquoteTXT(t *testing.T) {
+	assert.Equal(t, "v=spf1 -all", unquoteTXT(`"v=spf1 -all"`))
+	assert.Equal(t, "v=spf1 -all", unquoteTXT(`"v=spf1" " -all"`))
+	assert.Equal(t, `say "hi"`, unquoteTXT(`"say \"hi\""`))
+	assert.Equal(t, "plain text", unquoteTXT("plain text"))
+}
+
+func TestZonesService_DiffZones(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/staging.example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"www","type":"A","content":"192.0.2.1","ttl":3600}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[{"id":2,"name":"www","type":"A","content":"192.0.2.2","ttl":3600}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+
+	diff, err := client.Zones.DiffZones(context.Background(), "1010", "staging.example.com", "example.com", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, "staging.example.com", diff.FromZone)
+}
@@ -95,8 +95,15 @@ func (s *ZonesService) ListRecords(ctx context.Context, accountID string, zoneNa
 
 // CreateRecord creates a zone record.
 //
+// The regions in the record attributes are validated before the request is sent,
+// unless Client.SkipRegionValidation is set.
+//
 // See https://developer.dnsimple.com/v2/zones/records/#createZoneRecord
 func (s *ZonesService) CreateRecord(ctx context.Context, accountID string, zoneName string, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if err := s.validateRegions(recordAttributes.Regions); err != nil {
+		return nil, err
+	}
+
 	path := versioned(zoneRecordPath(accountID, zoneName, 0))
 	recordResponse := &ZoneRecordResponse{}
 
@@ -127,8 +134,15 @@ func (s *ZonesService) GetRecord(ctx context.Context, accountID string, zoneName
 
 // UpdateRecord updates a zone record.
 //
+// The regions in the record attributes are validated before the request is sent,
+// unless Client.SkipRegionValidation is set.
+//
 // See https://developer.dnsimple.com/v2/zones/records/#updateZoneRecord
 func (s *ZonesService) UpdateRecord(ctx context.Context, accountID string, zoneName string, recordID int64, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if err := s.validateRegions(recordAttributes.Regions); err != nil {
+		return nil, err
+	}
+
 	path := versioned(zoneRecordPath(accountID, zoneName, recordID))
 	recordResponse := &ZoneRecordResponse{}
 	resp, err := s.client.patch(ctx, path, recordAttributes, recordResponse)
@@ -156,3 +170,26 @@ func (s *ZonesService) DeleteRecord(ctx context.Context, accountID string, zoneN
 	recordResponse.HTTPResponse = resp
 	return recordResponse, nil
 }
+
+// listAllRecords pages through ListRecords and returns the records from every page.
+func (s *ZonesService) listAllRecords(ctx context.Context, accountID string, zoneName string, options *ZoneRecordListOptions) ([]ZoneRecord, error) {
+	pageOptions := ZoneRecordListOptions{}
+	if options != nil {
+		pageOptions = *options
+	}
+
+	var records []ZoneRecord
+	for page := 1; ; page++ {
+		pageOptions.Page = Int(page)
+
+		recordsResponse, err := s.ListRecords(ctx, accountID, zoneName, &pageOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, recordsResponse.Data...)
+		if recordsResponse.Pagination == nil || page >= recordsResponse.Pagination.TotalPages {
+			return records, nil
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// BatchZoneRecordUpdate represents a zone record update in a batch change.
+type BatchZoneRecordUpdate struct {
+	// The ID of the record to update.
+	ID int64 `json:"id"`
+	ZoneRecordAttributes
+}
+
+// BatchZoneRecordDelete represents a zone record deletion in a batch change.
+type BatchZoneRecordDelete struct {
+	// The ID of the record to delete.
+	ID int64 `json:"id"`
+}
+
+// BatchChangeZoneRecordsInput represents the changes to apply to the records
+// of a zone in a single request.
+type BatchChangeZoneRecordsInput struct {
+	Creates []ZoneRecordAttributes  `json:"creates,omitempty"`
+	Updates []BatchZoneRecordUpdate `json:"updates,omitempty"`
+	Deletes []BatchZoneRecordDelete `json:"deletes,omitempty"`
+}
+
+// BatchChangeZoneRecords represents the result of a batch change.
+//
+// Each section lists the results in the same order of the corresponding section of the input.
+type BatchChangeZoneRecords struct {
+	Creates []ZoneRecord            `json:"creates"`
+	Updates []ZoneRecord            `json:"updates"`
+	Deletes []BatchZoneRecordDelete `json:"deletes"`
+}
+
+// BatchChangeZoneRecordsResponse represents a response from an API method that returns a BatchChangeZoneRecords struct.
+type BatchChangeZoneRecordsResponse struct {
+	Response
+	Data *BatchChangeZoneRecords `json:"data"`
+}
+
+// BatchZoneRecordError represents the error of a single change in a batch.
+type BatchZoneRecordError struct {
+	// The position of the change in its section of the input.
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+	// detailed validation errors
+	AttributeErrors map[string][]string `json:"errors,omitempty"`
+}
+
+// BatchChangeZoneRecordsError represents an API response to a batch change
+// that failed because of one or more invalid changes.
+// When the batch fails no change is applied.
+type BatchChangeZoneRecordsError struct {
+	ErrorResponse
+
+	// The errors of the individual changes, per section.
+	Creates []BatchZoneRecordError
+	Updates []BatchZoneRecordError
+	Deletes []BatchZoneRecordError
+}
+
+// Error implements the error interface.
+func (e *BatchChangeZoneRecordsError) Error() string {
+	return fmt.Sprintf("%v (%d create, %d update, %d delete errors)",
+		e.ErrorResponse.Error(), len(e.Creates), len(e.Updates), len(e.Deletes))
+}
+
+// Unwrap returns the underlying *ErrorResponse, so that errors.As matches it
+// as for the other API errors.
+func (e *BatchChangeZoneRecordsError) Unwrap() error {
+	return &e.ErrorResponse
+}
+
+// BatchChangeRecords creates, updates and deletes zone records in a single request.
+//
+// The changes are applied atomically: if any of them is invalid, none is applied
+// and a *BatchChangeZoneRecordsError is returned with the errors of each invalid change.
+//
+// The regions of the created and updated records are validated before the request is sent,
+// as in CreateRecord and UpdateRecord.
+//
+// See https://developer.dnsimple.com/v2/zones/records/#batchChangeZoneRecords
+func (s *ZonesService) BatchChangeRecords(ctx context.Context, accountID string, zoneName string, input BatchChangeZoneRecordsInput) (*BatchChangeZoneRecordsResponse, error) {
+	for i, create := range input.Creates {
+		if err := s.validateRegions(create.Regions); err != nil {
+			return nil, fmt.Errorf("creates[%d]: %w", i, err)
+		}
+	}
+	for i, update := range input.Updates {
+		if err := s.validateRegions(update.Regions); err != nil {
+			return nil, fmt.Errorf("updates[%d]: %w", i, err)
+		}
+	}
+
+	path := versioned(fmt.Sprintf("/%v/zones/%v/batch", accountID, zoneName))
+	batchResponse := &BatchChangeZoneRecordsResponse{}
+
+	resp, err := s.client.post(ctx, path, input, batchResponse)
+	if err != nil {
+		var errorResponse *ErrorResponse
+		if errors.As(err, &errorResponse) {
+			return nil, newBatchChangeZoneRecordsError(errorResponse)
+		}
+		return nil, err
+	}
+
+	batchResponse.HTTPResponse = resp
+	return batchResponse, nil
+}
+
+// newBatchChangeZoneRecordsError extracts the errors of the individual changes from an error response.
+// The error response is returned unchanged if it doesn't contain errors of individual changes.
+func newBatchChangeZoneRecordsError(errorResponse *ErrorResponse) error {
+	var sections struct {
+		Creates []BatchZoneRecordError `json:"creates"`
+		Updates []BatchZoneRecordError `json:"updates"`
+		Deletes []BatchZoneRecordError `json:"deletes"`
+	}
+	if len(errorResponse.rawErrors) == 0 || json.Unmarshal(errorResponse.rawErrors, &sections) != nil {
+		return errorResponse
+	}
+	if sections.Creates == nil && sections.Updates == nil && sections.Deletes == nil {
+		return errorResponse
+	}
+
+	return &BatchChangeZoneRecordsError{
+		ErrorResponse: *errorResponse,
+		Creates:       sections.Creates,
+		Updates:       sections.Updates,
+		Deletes:       sections.Deletes,
+	}
+}
@@ -0,0 +1,143 @@
+package dnsimple
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonesService_BatchChangeRecords(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/batch", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/batchChangeZoneRecords/success.http")
+
+		testMethod(t, r, "POST")
+		testHeaders(t, r)
+
+		want := map[string]interface{}{
+			"creates": []interface{}{
+				map[string]interface{}{"name": "ab", "type": "A", "content": "3.2.3.4"},
+				map[string]interface{}{"name": "ab", "type": "A", "content": "4.2.3.4"},
+			},
+			"updates": []interface{}{
+				map[string]interface{}{"id": float64(309), "content": "3.2.3.40"},
+				map[string]interface{}{"id": float64(310), "content": "3.2.3.41"},
+			},
+			"deletes": []interface{}{
+				map[string]interface{}{"id": float64(67622509)},
+				map[string]interface{}{"id": float64(67622511)},
+			},
+		}
+		testRequestJSON(t, r, want)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	input := BatchChangeZoneRecordsInput{
+		Creates: []ZoneRecordAttributes{
+			{Name: String("ab"), Type: "A", Content: "3.2.3.4"},
+			{Name: String("ab"), Type: "A", Content: "4.2.3.4"},
+		},
+		Updates: []BatchZoneRecordUpdate{
+			{ID: 309, ZoneRecordAttributes: ZoneRecordAttributes{Content: "3.2.3.40"}},
+			{ID: 310, ZoneRecordAttributes: ZoneRecordAttributes{Content: "3.2.3.41"}},
+		},
+		Deletes: []BatchZoneRecordDelete{{ID: 67622509}, {ID: 67622511}},
+	}
+
+	batchResponse, err := client.Zones.BatchChangeRecords(context.Background(), "1010", "example.com", input)
+
+	assert.NoError(t, err)
+	batch := batchResponse.Data
+	assert.Len(t, batch.Creates, 2)
+	assert.Equal(t, int64(3291), batch.Creates[0].ID)
+	assert.Equal(t, "3.2.3.4", batch.Creates[0].Content)
+	assert.Len(t, batch.Updates, 2)
+	assert.Equal(t, int64(310), batch.Updates[1].ID)
+	assert.Equal(t, "3.2.3.41", batch.Updates[1].Content)
+	assert.Equal(t, []BatchZoneRecordDelete{{ID: 67622509}, {ID: 67622511}}, batch.Deletes)
+}
+
+func TestZonesService_BatchChangeRecords_CreateValidationFailed(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/batch", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/batchChangeZoneRecords/error_400_create_validation_failed.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	input := BatchChangeZoneRecordsInput{Creates: []ZoneRecordAttributes{{Name: String("ab"), Type: "SPAM", Content: "3.2.3.4"}}}
+
+	_, err := client.Zones.BatchChangeRecords(context.Background(), "1010", "example.com", input)
+
+	var got *BatchChangeZoneRecordsError
+	assert.ErrorAs(t, err, &got)
+	assert.Equal(t, "Validation failed", got.Message)
+	assert.Equal(t, []BatchZoneRecordError{{Index: 0, Message: "Validation failed", AttributeErrors: map[string][]string{"record_type": {"unsupported"}}}}, got.Creates)
+	assert.Empty(t, got.Updates)
+	assert.Empty(t, got.Deletes)
+	assert.Contains(t, got.Error(), "400 Validation failed (1 create, 0 update, 0 delete errors)")
+
+	var errorResponse *ErrorResponse
+	assert.ErrorAs(t, err, &errorResponse)
+	assert.Equal(t, http.StatusBadRequest, errorResponse.HTTPResponse.StatusCode)
+}
+
+func TestZonesService_BatchChangeRecords_DeleteValidationFailed(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/batch", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/batchChangeZoneRecords/error_400_delete_validation_failed.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	input := BatchChangeZoneRecordsInput{Deletes: []BatchZoneRecordDelete{{ID: 67622509}}}
+
+	_, err := client.Zones.BatchChangeRecords(context.Background(), "1010", "example.com", input)
+
+	var got *BatchChangeZoneRecordsError
+	assert.ErrorAs(t, err, &got)
+	assert.Equal(t, []BatchZoneRecordError{{Index: 0, Message: "Record not found ID=67622509"}}, got.Deletes)
+}
+
+func TestZonesService_BatchChangeRecords_NotFound(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/batch", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/notfound-zone.http")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	_, err := client.Zones.BatchChangeRecords(context.Background(), "1010", "example.com", BatchChangeZoneRecordsInput{})
+
+	var got *ErrorResponse
+	assert.ErrorAs(t, err, &got)
+	assert.Equal(t, http.StatusNotFound, got.HTTPResponse.StatusCode)
+}
+
+func TestZonesService_BatchChangeRecords_InvalidRegions(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	_, err := client.Zones.BatchChangeRecords(context.Background(), "1010", "example.com", BatchChangeZoneRecordsInput{
+		Creates: []ZoneRecordAttributes{{Type: "A", Content: "192.0.2.1", Regions: []string{"SV1"}}},
+		Updates: []BatchZoneRecordUpdate{{ID: 1, ZoneRecordAttributes: ZoneRecordAttributes{Regions: []string{"SV1"}}}, {ID: 2, ZoneRecordAttributes: ZoneRecordAttributes{Regions: []string{"XYZ"}}}},
+	})
+
+	assert.EqualError(t, err, `updates[1]: invalid region "XYZ"`)
+}
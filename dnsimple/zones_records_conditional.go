@@ -0,0 +1,137 @@
+package dnsimple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ZoneRecordConflictError is returned by the conditional update methods
+// when the record stored in DNSimple no longer matches the record previously read.
+type ZoneRecordConflictError struct {
+	// The record as it was read by the caller.
+	Expected ZoneRecord
+	// The record as it is currently stored in DNSimple.
+	Current ZoneRecord
+}
+
+// Error implements the error interface.
+func (e *ZoneRecordConflictError) Error() string {
+	return fmt.Sprintf("zone record %v in zone %v was modified concurrently (updated at %v, expected %v)",
+		e.Current.ID, e.Current.ZoneID, e.Current.UpdatedAt, e.Expected.UpdatedAt)
+}
+
+// IsZoneRecordConflict reports whether err is, or wraps, a *ZoneRecordConflictError.
+func IsZoneRecordConflict(err error) bool {
+	var conflictErr *ZoneRecordConflictError
+	return errors.As(err, &conflictErr)
+}
+
+// zoneRecordUnchanged reports whether current still matches the previously read record.
+func zoneRecordUnchanged(previous, current ZoneRecord) bool {
+	if previous.UpdatedAt != current.UpdatedAt {
+		return false
+	}
+	if previous.Type != current.Type ||
+		previous.Name != current.Name ||
+		previous.Content != current.Content ||
+		previous.TTL != current.TTL ||
+		previous.Priority != current.Priority {
+		return false
+	}
+	if len(previous.Regions) != len(current.Regions) {
+		return false
+	}
+	for i := range previous.Regions {
+		if previous.Regions[i] != current.Regions[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateRecordIfUnchanged updates a zone record only if it has not been modified
+// since previous was read.
+//
+// The current record is fetched and compared with previous: if the UpdatedAt timestamp
+// or any of the record content differs, the update is not sent and
+// a *ZoneRecordConflictError is returned.
+//
+// The DNSimple API does not support conditional requests, therefore the check
+// narrows the window for a lost update but cannot close it completely.
+func (s *ZonesService) UpdateRecordIfUnchanged(ctx context.Context, accountID string, zoneName string, previous ZoneRecord, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if previous.ID == 0 {
+		return nil, errors.New("previous record must have an ID")
+	}
+
+	currentResponse, err := s.GetRecord(ctx, accountID, zoneName, previous.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateIfUnchanged(ctx, accountID, zoneName, *currentResponse.Data, previous, recordAttributes)
+}
+
+// updateIfUnchanged updates the record if its current state, already fetched, matches the previous one.
+func (s *ZonesService) updateIfUnchanged(ctx context.Context, accountID string, zoneName string, current ZoneRecord, previous ZoneRecord, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if !zoneRecordUnchanged(previous, current) {
+		return nil, &ZoneRecordConflictError{Expected: previous, Current: current}
+	}
+
+	return s.UpdateRecord(ctx, accountID, zoneName, previous.ID, recordAttributes)
+}
+
+// ZoneRecordModifier computes the attributes to update from the current state of a zone record.
+type ZoneRecordModifier func(record ZoneRecord) (ZoneRecordAttributes, error)
+
+// CompareAndSwapRecord performs a read-modify-write cycle on a zone record.
+//
+// The record is fetched, passed to modify, and the resulting attributes are applied as with
+// UpdateRecordIfUnchanged. When a concurrent modification is detected the cycle is repeated
+// with the record fetched by the check, up to maxAttempts times. If maxAttempts is lower than 1 a single
+// attempt is made. The last *ZoneRecordConflictError is returned if all attempts fail.
+//
+// The record is fetched again for the check of the first attempt, right after the initial read:
+// modify may take a while, and the record can be modified concurrently in the meantime.
+// The record fetched by a failed check is reused by the next attempt, so each attempt
+// costs a single fetch.
+func (s *ZonesService) CompareAndSwapRecord(ctx context.Context, accountID string, zoneName string, recordID int64, maxAttempts int, modify ZoneRecordModifier) (*ZoneRecordResponse, error) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	recordResponse, err := s.GetRecord(ctx, accountID, zoneName, recordID)
+	if err != nil {
+		return nil, err
+	}
+	record := *recordResponse.Data
+
+	var conflictErr *ZoneRecordConflictError
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		recordAttributes, err := modify(record)
+		if err != nil {
+			return nil, err
+		}
+
+		// the check fetches the record again, to catch the modifications made while modify ran
+		currentResponse, err := s.GetRecord(ctx, accountID, zoneName, recordID)
+		if err != nil {
+			return nil, err
+		}
+
+		updateResponse, err := s.updateIfUnchanged(ctx, accountID, zoneName, *currentResponse.Data, record, recordAttributes)
+		if err == nil {
+			return updateResponse, nil
+		}
+		if !errors.As(err, &conflictErr) {
+			return nil, err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// the record fetched by the check is the starting point of the next attempt
+		record = conflictErr.Current
+	}
+
+	return nil, conflictErr
+}
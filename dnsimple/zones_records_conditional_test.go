@@ -0,0 +1,146 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZonesService_UpdateRecordIfUnchanged(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/records/5", func(w http.ResponseWriter, r *http.Request) {
+		var httpResponse *http.Response
+		switch r.Method {
+		case "GET":
+			httpResponse = httpResponseFixture(t, "/api/getZoneRecord/success.http")
+		case "PATCH":
+			httpResponse = httpResponseFixture(t, "/api/updateZoneRecord/success.http")
+
+			want := map[string]interface{}{"content": "mxb.example.com", "priority": float64(20)}
+			testRequestJSON(t, r, want)
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	previous := ZoneRecord{ID: 5, ZoneID: "example.com", Name: "", Content: "mxa.example.com", TTL: 600, Priority: 10, Type: "MX", Regions: []string{"SV1", "IAD"}, UpdatedAt: "2016-10-05T09:51:35Z"}
+	recordValues := ZoneRecordAttributes{Content: "mxb.example.com", Priority: 20}
+
+	recordResponse, err := client.Zones.UpdateRecordIfUnchanged(context.Background(), "1010", "example.com", previous, recordValues)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mxb.example.com", recordResponse.Data.Content)
+}
+
+func TestZonesService_UpdateRecordIfUnchanged_Conflict(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/records/5", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/getZoneRecord/success.http")
+
+		testMethod(t, r, "GET")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	previous := ZoneRecord{ID: 5, ZoneID: "example.com", Content: "mxa.example.com", TTL: 600, Priority: 10, Type: "MX", Regions: []string{"SV1", "IAD"}, UpdatedAt: "2016-10-04T00:00:00Z"}
+
+	_, err := client.Zones.UpdateRecordIfUnchanged(context.Background(), "1010", "example.com", previous, ZoneRecordAttributes{Content: "mxb.example.com"})
+
+	var got *ZoneRecordConflictError
+	assert.ErrorAs(t, err, &got)
+	assert.True(t, IsZoneRecordConflict(err))
+	assert.Equal(t, "2016-10-05T09:51:35Z", got.Current.UpdatedAt)
+	assert.Equal(t, "2016-10-04T00:00:00Z", got.Expected.UpdatedAt)
+}
+
+func TestZonesService_UpdateRecordIfUnchanged_ContentChanged(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/records/5", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/getZoneRecord/success.http")
+
+		testMethod(t, r, "GET")
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	previous := ZoneRecord{ID: 5, ZoneID: "example.com", Content: "mxz.example.com", TTL: 600, Priority: 10, Type: "MX", Regions: []string{"SV1", "IAD"}, UpdatedAt: "2016-10-05T09:51:35Z"}
+
+	_, err := client.Zones.UpdateRecordIfUnchanged(context.Background(), "1010", "example.com", previous, ZoneRecordAttributes{Content: "mxb.example.com"})
+
+	assert.True(t, IsZoneRecordConflict(err))
+}
+
+func TestZonesService_CompareAndSwapRecord(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	gets := 0
+	mux.HandleFunc("/v2/1010/zones/example.com/records/5", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			gets++
+			// The record is modified by someone else between the first read and the check.
+			updatedAt := "2016-10-05T09:51:35Z"
+			if gets > 1 {
+				updatedAt = "2016-10-06T00:00:00Z"
+			}
+			fmt.Fprintf(w, `{"data":{"id":5,"zone_id":"example.com","name":"","content":"mxa.example.com","ttl":600,"priority":10,"type":"MX","regions":["global"],"updated_at":%q}}`, updatedAt)
+		case "PATCH":
+			want := map[string]interface{}{"ttl": float64(1200)}
+			testRequestJSON(t, r, want)
+
+			httpResponse := httpResponseFixture(t, "/api/updateZoneRecord/success.http")
+			w.WriteHeader(httpResponse.StatusCode)
+			_, _ = io.Copy(w, httpResponse.Body)
+		}
+	})
+
+	modifications := 0
+	recordResponse, err := client.Zones.CompareAndSwapRecord(context.Background(), "1010", "example.com", 5, 3, func(record ZoneRecord) (ZoneRecordAttributes, error) {
+		modifications++
+		return ZoneRecordAttributes{TTL: record.TTL * 2}, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), recordResponse.Data.ID)
+	assert.Equal(t, 2, modifications)
+	assert.Equal(t, 3, gets)
+}
+
+func TestZonesService_CompareAndSwapRecord_ExhaustsAttempts(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	gets := 0
+	mux.HandleFunc("/v2/1010/zones/example.com/records/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		gets++
+		fmt.Fprintf(w, `{"data":{"id":5,"zone_id":"example.com","name":"","content":"mxa.example.com","type":"MX","updated_at":"2016-10-05T09:51:%02dZ"}}`, gets)
+	})
+
+	_, err := client.Zones.CompareAndSwapRecord(context.Background(), "1010", "example.com", 5, 2, func(record ZoneRecord) (ZoneRecordAttributes, error) {
+		return ZoneRecordAttributes{TTL: 60}, nil
+	})
+
+	assert.True(t, IsZoneRecordConflict(err))
+	assert.Equal(t, 3, gets)
+}
@@ -0,0 +1,371 @@
+package dnsimple
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	defaultOwnershipMarkerPrefix = "_owner"
+
+	ownershipHeritage         = "heritage=dnsimple"
+	ownershipOwnerKey         = "dnsimple/owner="
+	ownershipEncryptedPrefix  = "enc:"
+	ownershipWildcardLabel    = "_wildcard"
+	ownershipUnmanagedDisplay = "no owner"
+)
+
+// ZoneRecordOwnershipError is returned when a record can't be modified
+// because it is not owned by the current owner.
+type ZoneRecordOwnershipError struct {
+	Zone string
+	Name string
+	Type string
+	// The owner of the record, or an empty string if the record has no owner.
+	// When the ownership marker can't be decrypted, the owner is unknown and reported as "?".
+	Owner string
+}
+
+// Error implements the error interface.
+func (e *ZoneRecordOwnershipError) Error() string {
+	owner := e.Owner
+	if owner == "" {
+		owner = ownershipUnmanagedDisplay
+	}
+	return fmt.Sprintf("%v record %q in zone %v is owned by %v", e.Type, e.Name, e.Zone, owner)
+}
+
+// ZoneRecordOwnership manages zone records on behalf of an owner,
+// recording the owner in companion TXT records, similar to the external-dns TXT registry.
+//
+// For each name and type managed, a TXT marker is created at
+// <MarkerPrefix>.<type>.<name>, such as _owner.a.www for the A records at www.
+// Records whose marker belongs to another owner, or without a marker,
+// can't be modified or deleted.
+type ZoneRecordOwnership struct {
+	zones *ZonesService
+
+	// OwnerID identifies the owner of the records.
+	OwnerID string
+
+	// MarkerPrefix is the first label of the marker names. Defaults to "_owner".
+	MarkerPrefix string
+
+	// EncryptionKey, when set, is used to encrypt the marker content with AES-GCM.
+	// It must be 16, 24 or 32 bytes long.
+	EncryptionKey []byte
+}
+
+// NewZoneRecordOwnership returns a ZoneRecordOwnership that manages records on behalf of ownerID.
+func NewZoneRecordOwnership(zones *ZonesService, ownerID string) *ZoneRecordOwnership {
+	return &ZoneRecordOwnership{zones: zones, OwnerID: ownerID}
+}
+
+func (o *ZoneRecordOwnership) prefix() string {
+	if o.MarkerPrefix == "" {
+		return defaultOwnershipMarkerPrefix
+	}
+	return o.MarkerPrefix
+}
+
+// MarkerName returns the name of the TXT marker for the records with the given name and type.
+func (o *ZoneRecordOwnership) MarkerName(name string, recordType string) string {
+	marker := o.prefix() + "." + strings.ToLower(recordType)
+	if name == "" {
+		return marker
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	if labels[0] == "*" {
+		labels[0] = ownershipWildcardLabel
+	}
+	return marker + "." + strings.Join(labels, ".")
+}
+
+// parseMarkerName returns the name and type of the records a marker refers to.
+func (o *ZoneRecordOwnership) parseMarkerName(marker string) (name string, recordType string, ok bool) {
+	rest := strings.TrimPrefix(strings.ToLower(marker), o.prefix()+".")
+	if rest == strings.ToLower(marker) || rest == "" {
+		return "", "", false
+	}
+
+	labels := strings.SplitN(rest, ".", 2)
+	recordType = strings.ToUpper(labels[0])
+	if len(labels) == 2 {
+		name = labels[1]
+		if strings.HasPrefix(name, ownershipWildcardLabel) {
+			name = "*" + strings.TrimPrefix(name, ownershipWildcardLabel)
+		}
+	}
+	return name, recordType, true
+}
+
+// markerContent returns the content of the TXT marker for the current owner.
+func (o *ZoneRecordOwnership) markerContent() (string, error) {
+	plain := ownershipHeritage + "," + ownershipOwnerKey + o.OwnerID
+	if len(o.EncryptionKey) == 0 {
+		return plain, nil
+	}
+
+	aead, err := o.aead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plain), nil)
+	return ownershipEncryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (o *ZoneRecordOwnership) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(o.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// isMarkerContent reports whether content looks like an ownership marker, encrypted or not.
+func isMarkerContent(content string) bool {
+	content = unquoteTXT(content)
+	return strings.HasPrefix(content, ownershipHeritage+",") || strings.HasPrefix(content, ownershipEncryptedPrefix)
+}
+
+// markerOwner returns the owner stored in the marker content.
+// It returns "?" when the content is encrypted with a different key.
+func (o *ZoneRecordOwnership) markerOwner(content string) string {
+	content = unquoteTXT(content)
+
+	if strings.HasPrefix(content, ownershipEncryptedPrefix) {
+		if len(o.EncryptionKey) == 0 {
+			return "?"
+		}
+		sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(content, ownershipEncryptedPrefix))
+		if err != nil {
+			return "?"
+		}
+		aead, err := o.aead()
+		if err != nil || len(sealed) < aead.NonceSize() {
+			return "?"
+		}
+		plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+		if err != nil {
+			return "?"
+		}
+		content = string(plain)
+	}
+
+	for _, part := range strings.Split(content, ",") {
+		if strings.HasPrefix(part, ownershipOwnerKey) {
+			return strings.TrimPrefix(part, ownershipOwnerKey)
+		}
+	}
+	return "?"
+}
+
+// findMarker returns the marker record for the given name and type, or nil if there is none.
+func (o *ZoneRecordOwnership) findMarker(ctx context.Context, accountID string, zoneName string, name string, recordType string) (*ZoneRecord, error) {
+	records, err := o.zones.listAllRecords(ctx, accountID, zoneName, &ZoneRecordListOptions{Name: String(o.MarkerName(name, recordType)), Type: String("TXT")})
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if isMarkerContent(records[i].Content) {
+			return &records[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Owner returns the owner of the records with the given name and type.
+// It returns an empty string if the records have no ownership marker.
+func (o *ZoneRecordOwnership) Owner(ctx context.Context, accountID string, zoneName string, name string, recordType string) (string, error) {
+	marker, err := o.findMarker(ctx, accountID, zoneName, name, recordType)
+	if err != nil || marker == nil {
+		return "", err
+	}
+	return o.markerOwner(marker.Content), nil
+}
+
+// errOwnerIDRequired is returned by the methods that modify records when OwnerID is empty.
+var errOwnerIDRequired = errors.New("owner ID must be set")
+
+// checkOwner returns the marker of the records with the given name and type,
+// or a *ZoneRecordOwnershipError if they are not owned by the current owner.
+// Records without a marker are owned by nobody.
+func (o *ZoneRecordOwnership) checkOwner(ctx context.Context, accountID string, zoneName string, name string, recordType string) (*ZoneRecord, error) {
+	if o.OwnerID == "" {
+		return nil, errOwnerIDRequired
+	}
+	marker, err := o.findMarker(ctx, accountID, zoneName, name, recordType)
+	if err != nil {
+		return nil, err
+	}
+	if marker == nil {
+		return nil, &ZoneRecordOwnershipError{Zone: zoneName, Name: name, Type: recordType}
+	}
+
+	if owner := o.markerOwner(marker.Content); owner != o.OwnerID {
+		return nil, &ZoneRecordOwnershipError{Zone: zoneName, Name: name, Type: recordType, Owner: owner}
+	}
+	return marker, nil
+}
+
+// CreateRecord creates a zone record owned by the current owner.
+//
+// If there are no records with the same name and type, the ownership marker is created as well.
+// If the existing records belong to another owner, or have no owner,
+// a *ZoneRecordOwnershipError is returned.
+func (o *ZoneRecordOwnership) CreateRecord(ctx context.Context, accountID string, zoneName string, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if o.OwnerID == "" {
+		return nil, errOwnerIDRequired
+	}
+
+	name := ""
+	if recordAttributes.Name != nil {
+		name = *recordAttributes.Name
+	}
+	recordType := strings.ToUpper(recordAttributes.Type)
+
+	marker, err := o.findMarker(ctx, accountID, zoneName, name, recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	if marker != nil {
+		if owner := o.markerOwner(marker.Content); owner != o.OwnerID {
+			return nil, &ZoneRecordOwnershipError{Zone: zoneName, Name: name, Type: recordType, Owner: owner}
+		}
+	} else {
+		existing, err := o.zones.listAllRecords(ctx, accountID, zoneName, &ZoneRecordListOptions{Name: String(name), Type: String(recordType)})
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			return nil, &ZoneRecordOwnershipError{Zone: zoneName, Name: name, Type: recordType}
+		}
+
+		content, err := o.markerContent()
+		if err != nil {
+			return nil, err
+		}
+		markerAttributes := ZoneRecordAttributes{Type: "TXT", Name: String(o.MarkerName(name, recordType)), Content: content, TTL: recordAttributes.TTL}
+		if _, err := o.zones.CreateRecord(ctx, accountID, zoneName, markerAttributes); err != nil {
+			return nil, err
+		}
+	}
+
+	return o.zones.CreateRecord(ctx, accountID, zoneName, recordAttributes)
+}
+
+// UpdateRecord updates a zone record owned by the current owner.
+//
+// The name and the type of the record can't be changed, as the ownership marker refers to them.
+func (o *ZoneRecordOwnership) UpdateRecord(ctx context.Context, accountID string, zoneName string, recordID int64, recordAttributes ZoneRecordAttributes) (*ZoneRecordResponse, error) {
+	if o.OwnerID == "" {
+		return nil, errOwnerIDRequired
+	}
+
+	recordResponse, err := o.zones.GetRecord(ctx, accountID, zoneName, recordID)
+	if err != nil {
+		return nil, err
+	}
+	record := recordResponse.Data
+
+	if recordAttributes.Name != nil && !strings.EqualFold(*recordAttributes.Name, record.Name) {
+		return nil, errors.New("the name of an owned record can't be changed")
+	}
+	if recordAttributes.Type != "" && !strings.EqualFold(recordAttributes.Type, record.Type) {
+		return nil, errors.New("the type of an owned record can't be changed")
+	}
+
+	if _, err := o.checkOwner(ctx, accountID, zoneName, record.Name, record.Type); err != nil {
+		return nil, err
+	}
+
+	return o.zones.UpdateRecord(ctx, accountID, zoneName, recordID, recordAttributes)
+}
+
+// DeleteRecord PERMANENTLY deletes a zone record owned by the current owner.
+//
+// The ownership marker is deleted together with the last record with the same name and type.
+func (o *ZoneRecordOwnership) DeleteRecord(ctx context.Context, accountID string, zoneName string, recordID int64) (*ZoneRecordResponse, error) {
+	if o.OwnerID == "" {
+		return nil, errOwnerIDRequired
+	}
+
+	recordResponse, err := o.zones.GetRecord(ctx, accountID, zoneName, recordID)
+	if err != nil {
+		return nil, err
+	}
+	record := recordResponse.Data
+
+	marker, err := o.checkOwner(ctx, accountID, zoneName, record.Name, record.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteResponse, err := o.zones.DeleteRecord(ctx, accountID, zoneName, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining, err := o.zones.listAllRecords(ctx, accountID, zoneName, &ZoneRecordListOptions{Name: String(record.Name), Type: String(record.Type)})
+	if err != nil {
+		return nil, err
+	}
+	if len(remaining) == 0 && marker != nil {
+		if _, err := o.zones.DeleteRecord(ctx, accountID, zoneName, marker.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return deleteResponse, nil
+}
+
+// OrphanedMarker represents an ownership marker whose records no longer exist.
+type OrphanedMarker struct {
+	// The marker TXT record.
+	Marker ZoneRecord
+	// The name and type of the records the marker refers to.
+	Name string
+	Type string
+	// The owner stored in the marker, or "?" if it can't be decrypted.
+	Owner string
+}
+
+// ListOrphanedMarkers lists the ownership markers of a zone, of any owner,
+// that refer to records that no longer exist. They can be deleted with ZonesService.DeleteRecord.
+func (o *ZoneRecordOwnership) ListOrphanedMarkers(ctx context.Context, accountID string, zoneName string) ([]OrphanedMarker, error) {
+	records, err := o.zones.listAllRecords(ctx, accountID, zoneName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, record := range records {
+		existing[strings.ToLower(record.Name)+" "+strings.ToUpper(record.Type)] = true
+	}
+
+	var orphans []OrphanedMarker
+	for _, record := range records {
+		if record.Type != "TXT" || !isMarkerContent(record.Content) {
+			continue
+		}
+		name, recordType, ok := o.parseMarkerName(record.Name)
+		if !ok || existing[name+" "+recordType] {
+			continue
+		}
+		orphans = append(orphans, OrphanedMarker{Marker: record, Name: name, Type: recordType, Owner: o.markerOwner(record.Content)})
+	}
+	return orphans, nil
+}
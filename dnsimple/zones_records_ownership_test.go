@@ -0,0 +1,302 @@
+package dnsimple
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeZoneRecords is an in-memory implementation of the zone records endpoints of a single zone.
+type fakeZoneRecords struct {
+	mu      sync.Mutex
+	nextID  int64
+	records []ZoneRecord
+}
+
+func newFakeZoneRecords(records ...ZoneRecord) *fakeZoneRecords {
+	f := &fakeZoneRecords{nextID: 100}
+	for _, record := range records {
+		f.add(record)
+	}
+	return f
+}
+
+func (f *fakeZoneRecords) add(record ZoneRecord) ZoneRecord {
+	f.nextID++
+	record.ID = f.nextID
+	f.records = append(f.records, record)
+	return record
+}
+
+func (f *fakeZoneRecords) find(name, recordType string) []ZoneRecord {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var found []ZoneRecord
+	for _, record := range f.records {
+		if record.Name == name && record.Type == recordType {
+			found = append(found, record)
+		}
+	}
+	return found
+}
+
+func (f *fakeZoneRecords) register(t *testing.T, accountID string, zoneName string) {
+	base := "/v2/" + accountID + "/zones/" + zoneName + "/records"
+
+	mux.HandleFunc(base, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.Method {
+		case "GET":
+			query := r.URL.Query()
+			data := []ZoneRecord{}
+			for _, record := range f.records {
+				if _, ok := query["name"]; ok && record.Name != query.Get("name") {
+					continue
+				}
+				if query.Get("type") != "" && record.Type != query.Get("type") {
+					continue
+				}
+				data = append(data, record)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data":       data,
+				"pagination": Pagination{CurrentPage: 1, PerPage: 100, TotalPages: 1, TotalEntries: len(data)},
+			})
+		case "POST":
+			var attributes ZoneRecordAttributes
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+			record := ZoneRecord{ZoneID: zoneName, Type: attributes.Type, Content: attributes.Content, TTL: attributes.TTL, Priority: attributes.Priority, Regions: attributes.Regions}
+			if attributes.Name != nil {
+				record.Name = *attributes.Name
+			}
+			record = f.add(record)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": record})
+		}
+	})
+
+	mux.HandleFunc(base+"/", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		id, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, base+"/"), 10, 64)
+		index := -1
+		for i, record := range f.records {
+			if record.ID == id {
+				index = i
+			}
+		}
+		if index < 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Record not found"}`))
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": f.records[index]})
+		case "PATCH":
+			var attributes ZoneRecordAttributes
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&attributes))
+			record := &f.records[index]
+			if attributes.Name != nil {
+				record.Name = *attributes.Name
+			}
+			if attributes.Content != "" {
+				record.Content = attributes.Content
+			}
+			if attributes.TTL != 0 {
+				record.TTL = attributes.TTL
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": *record})
+		case "DELETE":
+			f.records = append(f.records[:index], f.records[index+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+func TestZoneRecordOwnership_MarkerName(t *testing.T) {
+	ownership := NewZoneRecordOwnership(nil, "team-a")
+
+	assert.Equal(t, "_owner.a.www", ownership.MarkerName("www", "A"))
+	assert.Equal(t, "_owner.mx", ownership.MarkerName("", "MX"))
+	assert.Equal(t, "_owner.cname._wildcard.dev", ownership.MarkerName("*.dev", "CNAME"))
+
+	name, recordType, ok := ownership.parseMarkerName("_owner.cname._wildcard.dev")
+	assert.True(t, ok)
+	assert.Equal(t, "*.dev", name)
+	assert.Equal(t, "CNAME", recordType)
+
+	name, recordType, ok = ownership.parseMarkerName("_owner.mx")
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+	assert.Equal(t, "MX", recordType)
+
+	_, _, ok = ownership.parseMarkerName("www")
+	assert.False(t, ok)
+}
+
+func TestZoneRecordOwnership_Lifecycle(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	zone := newFakeZoneRecords()
+	zone.register(t, "1010", "example.com")
+
+	ownership := NewZoneRecordOwnership(client.Zones, "team-a")
+	ctx := context.Background()
+
+	created, err := ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("www"), Type: "A", Content: "192.0.2.1", TTL: 300})
+	assert.NoError(t, err)
+
+	markers := zone.find("_owner.a.www", "TXT")
+	assert.Len(t, markers, 1)
+	assert.Equal(t, "heritage=dnsimple,dnsimple/owner=team-a", markers[0].Content)
+
+	owner, err := ownership.Owner(ctx, "1010", "example.com", "www", "A")
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", owner)
+
+	second, err := ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("www"), Type: "A", Content: "192.0.2.2", TTL: 300})
+	assert.NoError(t, err)
+	assert.Len(t, zone.find("_owner.a.www", "TXT"), 1)
+
+	_, err = ownership.UpdateRecord(ctx, "1010", "example.com", created.Data.ID, ZoneRecordAttributes{Content: "192.0.2.3"})
+	assert.NoError(t, err)
+
+	_, err = ownership.DeleteRecord(ctx, "1010", "example.com", created.Data.ID)
+	assert.NoError(t, err)
+	assert.Len(t, zone.find("_owner.a.www", "TXT"), 1)
+
+	_, err = ownership.DeleteRecord(ctx, "1010", "example.com", second.Data.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, zone.find("_owner.a.www", "TXT"))
+	assert.Empty(t, zone.find("www", "A"))
+}
+
+func TestZoneRecordOwnership_RefusesOtherOwner(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	zone := newFakeZoneRecords(
+		ZoneRecord{Name: "www", Type: "A", Content: "192.0.2.1"},
+		ZoneRecord{Name: "_owner.a.www", Type: "TXT", Content: "heritage=dnsimple,dnsimple/owner=team-b"},
+		ZoneRecord{Name: "legacy", Type: "A", Content: "192.0.2.9"},
+	)
+	zone.register(t, "1010", "example.com")
+
+	ownership := NewZoneRecordOwnership(client.Zones, "team-a")
+	ctx := context.Background()
+	www := zone.find("www", "A")[0]
+	legacy := zone.find("legacy", "A")[0]
+
+	_, err := ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("www"), Type: "A", Content: "192.0.2.2"})
+	var ownershipErr *ZoneRecordOwnershipError
+	assert.ErrorAs(t, err, &ownershipErr)
+	assert.Equal(t, "team-b", ownershipErr.Owner)
+
+	_, err = ownership.UpdateRecord(ctx, "1010", "example.com", www.ID, ZoneRecordAttributes{Content: "192.0.2.2"})
+	assert.ErrorAs(t, err, &ownershipErr)
+
+	_, err = ownership.DeleteRecord(ctx, "1010", "example.com", www.ID)
+	assert.ErrorAs(t, err, &ownershipErr)
+	assert.Len(t, zone.find("www", "A"), 1)
+
+	_, err = ownership.DeleteRecord(ctx, "1010", "example.com", legacy.ID)
+	assert.ErrorAs(t, err, &ownershipErr)
+	assert.Equal(t, "", ownershipErr.Owner)
+	assert.Equal(t, `A record "legacy" in zone example.com is owned by no owner`, err.Error())
+
+	_, err = ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("legacy"), Type: "A", Content: "192.0.2.2"})
+	assert.ErrorAs(t, err, &ownershipErr)
+}
+
+func TestZoneRecordOwnership_RequiresOwnerID(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	zone := newFakeZoneRecords(ZoneRecord{Name: "legacy", Type: "A", Content: "192.0.2.9"})
+	zone.register(t, "1010", "example.com")
+
+	ownership := NewZoneRecordOwnership(client.Zones, "")
+	ctx := context.Background()
+	legacy := zone.find("legacy", "A")[0]
+
+	_, err := ownership.UpdateRecord(ctx, "1010", "example.com", legacy.ID, ZoneRecordAttributes{Content: "192.0.2.2"})
+	assert.EqualError(t, err, "owner ID must be set")
+
+	_, err = ownership.DeleteRecord(ctx, "1010", "example.com", legacy.ID)
+	assert.EqualError(t, err, "owner ID must be set")
+
+	_, err = ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("new"), Type: "A", Content: "192.0.2.2"})
+	assert.EqualError(t, err, "owner ID must be set")
+
+	assert.Equal(t, []ZoneRecord{legacy}, zone.find("legacy", "A"))
+	assert.Empty(t, zone.find("new", "A"))
+}
+
+func TestZoneRecordOwnership_Encrypted(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	zone := newFakeZoneRecords()
+	zone.register(t, "1010", "example.com")
+
+	ownership := NewZoneRecordOwnership(client.Zones, "team-a")
+	ownership.EncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+	ctx := context.Background()
+
+	_, err := ownership.CreateRecord(ctx, "1010", "example.com", ZoneRecordAttributes{Name: String("api"), Type: "CNAME", Content: "example.net"})
+	assert.NoError(t, err)
+
+	marker := zone.find("_owner.cname.api", "TXT")[0]
+	assert.True(t, strings.HasPrefix(marker.Content, "enc:"))
+	assert.NotContains(t, marker.Content, "team-a")
+
+	owner, err := ownership.Owner(ctx, "1010", "example.com", "api", "CNAME")
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", owner)
+
+	other := NewZoneRecordOwnership(client.Zones, "team-a")
+	other.EncryptionKey = []byte("fedcba9876543210fedcba9876543210")
+	owner, err = other.Owner(ctx, "1010", "example.com", "api", "CNAME")
+	assert.NoError(t, err)
+	assert.Equal(t, "?", owner)
+}
+
+func TestZoneRecordOwnership_ListOrphanedMarkers(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	zone := newFakeZoneRecords(
+		ZoneRecord{Name: "www", Type: "A", Content: "192.0.2.1"},
+		ZoneRecord{Name: "_owner.a.www", Type: "TXT", Content: "heritage=dnsimple,dnsimple/owner=team-a"},
+		ZoneRecord{Name: "_owner.aaaa.www", Type: "TXT", Content: `"heritage=dnsimple,dnsimple/owner=team-b"`},
+		ZoneRecord{Name: "_owner.mx", Type: "TXT", Content: "enc:Zm9v"},
+		ZoneRecord{Name: "_owner.txt", Type: "TXT", Content: "not a marker"},
+	)
+	zone.register(t, "1010", "example.com")
+
+	ownership := NewZoneRecordOwnership(client.Zones, "team-a")
+
+	orphans, err := ownership.ListOrphanedMarkers(context.Background(), "1010", "example.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, orphans, 2)
+	assert.Equal(t, "www", orphans[0].Name)
+	assert.Equal(t, "AAAA", orphans[0].Type)
+	assert.Equal(t, "team-b", orphans[0].Owner)
+	assert.Equal(t, "", orphans[1].Name)
+	assert.Equal(t, "MX", orphans[1].Type)
+	assert.Equal(t, "?", orphans[1].Owner)
+}
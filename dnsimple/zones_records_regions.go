@@ -0,0 +1,123 @@
+package dnsimple
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ZoneRecordRegion represents a region code used in ZoneRecord.Regions and ZoneRecordAttributes.Regions.
+type ZoneRecordRegion string
+
+// The regions where a zone record can be served.
+//
+// Records are served from every region by default (ZoneRecordRegionGlobal).
+// Serving a record from specific regions requires a plan with regional records:
+// the API rejects regional records for accounts without them.
+//
+// See https://support.dnsimple.com/articles/regional-records/
+const (
+	ZoneRecordRegionGlobal ZoneRecordRegion = "global"
+	ZoneRecordRegionSV1    ZoneRecordRegion = "SV1"
+	ZoneRecordRegionORD    ZoneRecordRegion = "ORD"
+	ZoneRecordRegionIAD    ZoneRecordRegion = "IAD"
+	ZoneRecordRegionAMS    ZoneRecordRegion = "AMS"
+	ZoneRecordRegionTKO    ZoneRecordRegion = "TKO"
+	ZoneRecordRegionSYD    ZoneRecordRegion = "SYD"
+	ZoneRecordRegionCDG    ZoneRecordRegion = "CDG"
+	ZoneRecordRegionFRA    ZoneRecordRegion = "FRA"
+)
+
+// ZoneRecordRegionInfo describes a region in the catalog returned by ZoneRecordRegions.
+type ZoneRecordRegionInfo struct {
+	Code     ZoneRecordRegion `json:"code"`
+	Location string           `json:"location"`
+	// Regional is false for the global region, that is available in every plan,
+	// and true for the regions that require a plan with regional records.
+	Regional bool `json:"regional"`
+}
+
+var zoneRecordRegions = []ZoneRecordRegionInfo{
+	{Code: ZoneRecordRegionGlobal, Location: "All regions", Regional: false},
+	{Code: ZoneRecordRegionSV1, Location: "San Jose, US", Regional: true},
+	{Code: ZoneRecordRegionORD, Location: "Chicago, US", Regional: true},
+	{Code: ZoneRecordRegionIAD, Location: "Ashburn, US", Regional: true},
+	{Code: ZoneRecordRegionAMS, Location: "Amsterdam, NL", Regional: true},
+	{Code: ZoneRecordRegionTKO, Location: "Tokyo, JP", Regional: true},
+	{Code: ZoneRecordRegionSYD, Location: "Sydney, AU", Regional: true},
+	{Code: ZoneRecordRegionCDG, Location: "Paris, FR", Regional: true},
+	{Code: ZoneRecordRegionFRA, Location: "Frankfurt, DE", Regional: true},
+}
+
+// ZoneRecordRegions returns the catalog of the regions where a zone record can be served.
+//
+// The catalog is the same for every account. The regions included in the plan of an account
+// are not available: the API doesn't expose which plans include regional records,
+// and rejects the regional records of accounts whose plan doesn't include them.
+func ZoneRecordRegions() []ZoneRecordRegionInfo {
+	return append([]ZoneRecordRegionInfo(nil), zoneRecordRegions...)
+}
+
+// ValidateZoneRecordRegions checks that the regions are known region codes.
+// The global region can't be combined with other regions, and a region can't be repeated.
+// An empty list is valid, and means the record is served globally.
+//
+// Only the region codes are checked, not whether the plan of the account includes regional records.
+func ValidateZoneRecordRegions(regions []string) error {
+	seen := map[string]bool{}
+	for _, region := range regions {
+		if !knownZoneRecordRegion(region) {
+			if knownZoneRecordRegion(strings.ToUpper(region)) || knownZoneRecordRegion(strings.ToLower(region)) {
+				return fmt.Errorf("invalid region %q: region codes are case sensitive", region)
+			}
+			return fmt.Errorf("invalid region %q", region)
+		}
+		if seen[region] {
+			return fmt.Errorf("duplicate region %q", region)
+		}
+		seen[region] = true
+	}
+
+	if seen[string(ZoneRecordRegionGlobal)] && len(regions) > 1 {
+		return fmt.Errorf("region %q can't be combined with other regions", ZoneRecordRegionGlobal)
+	}
+	return nil
+}
+
+func (s *ZonesService) validateRegions(regions []string) error {
+	if s.client.SkipRegionValidation {
+		return nil
+	}
+	return ValidateZoneRecordRegions(regions)
+}
+
+func knownZoneRecordRegion(region string) bool {
+	for _, info := range zoneRecordRegions {
+		if string(info.Code) == region {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupRecordsByRegion groups zone records by the regions they are served from.
+//
+// Records without regions are grouped under ZoneRecordRegionGlobal,
+// and records served from several regions appear in each of their groups.
+func GroupRecordsByRegion(records []ZoneRecord) map[ZoneRecordRegion][]ZoneRecord {
+	groups := map[ZoneRecordRegion][]ZoneRecord{}
+	for _, record := range records {
+		if len(record.Regions) == 0 {
+			groups[ZoneRecordRegionGlobal] = append(groups[ZoneRecordRegionGlobal], record)
+			continue
+		}
+		for _, region := range record.Regions {
+			groups[ZoneRecordRegion(region)] = append(groups[ZoneRecordRegion(region)], record)
+		}
+	}
+
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+	}
+	return groups
+}
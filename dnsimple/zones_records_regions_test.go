@@ -0,0 +1,88 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZoneRecordRegions(t *testing.T) {
+	regions := ZoneRecordRegions()
+
+	assert.Equal(t, ZoneRecordRegionGlobal, regions[0].Code)
+	assert.False(t, regions[0].Regional)
+	for _, info := range regions[1:] {
+		assert.True(t, info.Regional, info.Code)
+	}
+
+	regions[0].Code = "changed"
+	assert.Equal(t, ZoneRecordRegionGlobal, ZoneRecordRegions()[0].Code)
+}
+
+func TestValidateZoneRecordRegions(t *testing.T) {
+	assert.NoError(t, ValidateZoneRecordRegions(nil))
+	assert.NoError(t, ValidateZoneRecordRegions([]string{}))
+	assert.NoError(t, ValidateZoneRecordRegions([]string{"global"}))
+	assert.NoError(t, ValidateZoneRecordRegions([]string{"SV1", "IAD", "FRA"}))
+
+	assert.EqualError(t, ValidateZoneRecordRegions([]string{"XYZ"}), `invalid region "XYZ"`)
+	assert.EqualError(t, ValidateZoneRecordRegions([]string{"sv1"}), `invalid region "sv1": region codes are case sensitive`)
+	assert.EqualError(t, ValidateZoneRecordRegions([]string{"SV1", "SV1"}), `duplicate region "SV1"`)
+	assert.EqualError(t, ValidateZoneRecordRegions([]string{"global", "SV1"}), `region "global" can't be combined with other regions`)
+}
+
+func TestZonesService_CreateRecord_InvalidRegions(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	_, err := client.Zones.CreateRecord(context.Background(), "1010", "example.com", ZoneRecordAttributes{Type: "A", Content: "192.0.2.1", Regions: []string{"XYZ"}})
+	assert.EqualError(t, err, `invalid region "XYZ"`)
+
+	_, err = client.Zones.UpdateRecord(context.Background(), "1010", "example.com", 1, ZoneRecordAttributes{Regions: []string{"global", "AMS"}})
+	assert.Error(t, err)
+}
+
+func TestZonesService_CreateRecord_SkipRegionValidation(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestJSON(t, r, map[string]interface{}{"type": "A", "content": "192.0.2.1", "regions": []interface{}{"NEW"}})
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":1,"zone_id":"example.com","name":"","content":"192.0.2.1","type":"A","regions":["NEW"]}}`)
+	})
+
+	client.SkipRegionValidation = true
+	recordResponse, err := client.Zones.CreateRecord(context.Background(), "1010", "example.com", ZoneRecordAttributes{Type: "A", Content: "192.0.2.1", Regions: []string{"NEW"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NEW"}, recordResponse.Data.Regions)
+}
+
+func TestGroupRecordsByRegion(t *testing.T) {
+	records := []ZoneRecord{
+		{ID: 3, Name: "www", Type: "A", Regions: []string{"SV1", "IAD"}},
+		{ID: 1, Name: "", Type: "A"},
+		{ID: 2, Name: "api", Type: "A", Regions: []string{"global"}},
+		{ID: 4, Name: "cdn", Type: "A", Regions: []string{"IAD"}},
+	}
+
+	groups := GroupRecordsByRegion(records)
+
+	assert.Len(t, groups, 3)
+	assert.Equal(t, []int64{1, 2}, zoneRecordIDs(groups[ZoneRecordRegionGlobal]))
+	assert.Equal(t, []int64{3}, zoneRecordIDs(groups[ZoneRecordRegionSV1]))
+	assert.Equal(t, []int64{3, 4}, zoneRecordIDs(groups[ZoneRecordRegionIAD]))
+}
+
+func zoneRecordIDs(records []ZoneRecord) []int64 {
+	ids := make([]int64, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
@@ -0,0 +1,255 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ZoneRecordSearchQuery specifies the criteria a zone record must match
+// to be returned by ZonesService.SearchRecords.
+//
+// Empty criteria are ignored; every non-empty criterion must match.
+type ZoneRecordSearchQuery struct {
+	// Select zones where the name contains given string.
+	ZoneNameLike string
+
+	// Select records whose content is exactly the given value.
+	// The comparison ignores case and a trailing dot.
+	Content string
+
+	// Select records whose content is an IP address within the given CIDR block,
+	// such as 192.0.2.0/24 or 2001:db8::/32.
+	ContentCIDR string
+
+	// Select records whose content matches the regular expression.
+	ContentRegexp *regexp.Regexp
+
+	// Select records of any of the given types.
+	// Eg. A, AAAA, CNAME.
+	Types []string
+
+	// Select records with the given name, relative to the zone apex.
+	// Use an empty Name together with MatchApex to select the apex.
+	Name string
+
+	// Select records at the zone apex.
+	MatchApex bool
+
+	// Select records whose name, relative to the zone apex, matches the regular expression.
+	NameRegexp *regexp.Regexp
+
+	// Select records with a TTL greater than or equal to MinTTL.
+	MinTTL int
+
+	// Select records with a TTL less than or equal to MaxTTL.
+	MaxTTL int
+}
+
+// ZoneRecordSearchOptions specifies the optional parameters you can provide
+// to customize the ZonesService.SearchRecords method.
+type ZoneRecordSearchOptions struct {
+	// The number of zones crawled concurrently. Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// See RateLimitReserve.
+	RateLimitReserve RateLimitReserve
+}
+
+// ZoneRecordSearchResult represents a single result streamed by ZonesService.SearchRecords.
+//
+// Either Record is set, with the Zone it belongs to, or Err is set when the records
+// of the Zone (or the zones themselves) could not be listed.
+type ZoneRecordSearchResult struct {
+	Zone   Zone
+	Record ZoneRecord
+	Err    error
+}
+
+type zoneRecordMatcher struct {
+	query   ZoneRecordSearchQuery
+	network *net.IPNet
+	types   map[string]bool
+}
+
+func newZoneRecordMatcher(query ZoneRecordSearchQuery) (*zoneRecordMatcher, error) {
+	m := &zoneRecordMatcher{query: query}
+
+	if query.ContentCIDR != "" {
+		_, network, err := net.ParseCIDR(query.ContentCIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content CIDR: %w", err)
+		}
+		m.network = network
+	}
+
+	if len(query.Types) > 0 {
+		m.types = map[string]bool{}
+		for _, t := range query.Types {
+			m.types[strings.ToUpper(t)] = true
+		}
+	}
+
+	return m, nil
+}
+
+func (m *zoneRecordMatcher) match(record ZoneRecord) bool {
+	q := m.query
+
+	if m.types != nil && !m.types[strings.ToUpper(record.Type)] {
+		return false
+	}
+	if (q.Name != "" || q.MatchApex) && !strings.EqualFold(record.Name, q.Name) {
+		return false
+	}
+	if q.NameRegexp != nil && !q.NameRegexp.MatchString(record.Name) {
+		return false
+	}
+	if q.MinTTL != 0 && record.TTL < q.MinTTL {
+		return false
+	}
+	if q.MaxTTL != 0 && record.TTL > q.MaxTTL {
+		return false
+	}
+	if q.Content != "" && normalizeName(record.Content) != normalizeName(q.Content) {
+		return false
+	}
+	if q.ContentRegexp != nil && !q.ContentRegexp.MatchString(record.Content) {
+		return false
+	}
+	if m.network != nil {
+		ip := net.ParseIP(record.Content)
+		if ip == nil || !m.network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// listOptions returns the filters that can be applied by the API
+// to reduce the number of records to page through.
+func (m *zoneRecordMatcher) listOptions() *ZoneRecordListOptions {
+	options := &ZoneRecordListOptions{}
+	if len(m.query.Types) == 1 {
+		options.Type = String(strings.ToUpper(m.query.Types[0]))
+	}
+	if m.query.Name != "" || m.query.MatchApex {
+		options.Name = String(m.query.Name)
+	}
+	return options
+}
+
+// SearchRecords searches the records of every zone in the account.
+//
+// Zones are crawled concurrently, and the matching records are streamed on the returned channel
+// as they are found. The channel is closed once every zone has been searched,
+// or the context is canceled.
+func (s *ZonesService) SearchRecords(ctx context.Context, accountID string, query ZoneRecordSearchQuery, options *ZoneRecordSearchOptions) (<-chan ZoneRecordSearchResult, error) {
+	matcher, err := newZoneRecordMatcher(query)
+	if err != nil {
+		return nil, err
+	}
+
+	searchOptions := ZoneRecordSearchOptions{}
+	if options != nil {
+		searchOptions = *options
+	}
+	searchOptions.Concurrency = concurrency(searchOptions.Concurrency)
+
+	budget := newRateBudget(int(searchOptions.RateLimitReserve))
+	results := make(chan ZoneRecordSearchResult)
+	zones := make(chan Zone)
+
+	send := func(result ZoneRecordSearchResult) bool {
+		select {
+		case results <- result:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < searchOptions.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for zone := range zones {
+				s.searchZone(ctx, accountID, zone, matcher, budget, send)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		defer wg.Wait()
+		defer close(zones)
+
+		zoneOptions := &ZoneListOptions{}
+		if query.ZoneNameLike != "" {
+			zoneOptions.NameLike = String(query.ZoneNameLike)
+		}
+
+		for page := 1; ; page++ {
+			zoneOptions.Page = Int(page)
+
+			if err := budget.wait(ctx); err != nil {
+				return
+			}
+			zonesResponse, err := s.ListZones(ctx, accountID, zoneOptions)
+			if err != nil {
+				send(ZoneRecordSearchResult{Err: err})
+				return
+			}
+			budget.update(&zonesResponse.Response)
+
+			for _, zone := range zonesResponse.Data {
+				select {
+				case zones <- zone:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if zonesResponse.Pagination == nil || page >= zonesResponse.Pagination.TotalPages {
+				return
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+func (s *ZonesService) searchZone(ctx context.Context, accountID string, zone Zone, matcher *zoneRecordMatcher, budget *rateBudget, send func(ZoneRecordSearchResult) bool) {
+	listOptions := matcher.listOptions()
+
+	for page := 1; ; page++ {
+		listOptions.Page = Int(page)
+
+		if err := budget.wait(ctx); err != nil {
+			return
+		}
+		recordsResponse, err := s.ListRecords(ctx, accountID, zone.Name, listOptions)
+		if err != nil {
+			send(ZoneRecordSearchResult{Zone: zone, Err: err})
+			return
+		}
+		budget.update(&recordsResponse.Response)
+
+		for _, record := range recordsResponse.Data {
+			if !matcher.match(record) {
+				continue
+			}
+			if !send(ZoneRecordSearchResult{Zone: zone, Record: record}) {
+				return
+			}
+		}
+
+		if recordsResponse.Pagination == nil || page >= recordsResponse.Pagination.TotalPages {
+			return
+		}
+	}
+}
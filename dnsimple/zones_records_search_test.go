@@ -0,0 +1,148 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSearchMockServer(t *testing.T) {
+	mux.HandleFunc("/v2/1010/zones", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com"}],"pagination":{"current_page":1,"per_page":1,"total_entries":2,"total_pages":2}}`)
+		case "2":
+			fmt.Fprint(w, `{"data":[{"id":2,"name":"example.net"}],"pagination":{"current_page":2,"per_page":1,"total_entries":2,"total_pages":2}}`)
+		}
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"id":11,"name":"","type":"A","content":"192.0.2.1","ttl":3600},
+			{"id":12,"name":"www","type":"A","content":"198.51.100.1","ttl":60},
+			{"id":13,"name":"www","type":"TXT","content":"192.0.2.1","ttl":3600}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":3,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.net/records", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[
+			{"id":21,"name":"api","type":"A","content":"192.0.2.77","ttl":300},
+			{"id":22,"name":"alias","type":"CNAME","content":"api.example.net.","ttl":300}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+}
+
+func collectSearchResults(t *testing.T, results <-chan ZoneRecordSearchResult) []ZoneRecordSearchResult {
+	var collected []ZoneRecordSearchResult
+	for result := range results {
+		assert.NoError(t, result.Err)
+		collected = append(collected, result)
+	}
+	sort.Slice(collected, func(i, j int) bool { return collected[i].Record.ID < collected[j].Record.ID })
+	return collected
+}
+
+func TestZonesService_SearchRecords_CIDR(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupSearchMockServer(t)
+
+	results, err := client.Zones.SearchRecords(context.Background(), "1010", ZoneRecordSearchQuery{ContentCIDR: "192.0.2.0/24"}, nil)
+	assert.NoError(t, err)
+
+	collected := collectSearchResults(t, results)
+	assert.Len(t, collected, 3)
+	assert.Equal(t, int64(11), collected[0].Record.ID)
+	assert.Equal(t, "example.com", collected[0].Zone.Name)
+	assert.Equal(t, int64(13), collected[1].Record.ID)
+	assert.Equal(t, int64(21), collected[2].Record.ID)
+	assert.Equal(t, "example.net", collected[2].Zone.Name)
+}
+
+func TestZonesService_SearchRecords_Criteria(t *testing.T) {
+	tests := []struct {
+		name  string
+		query ZoneRecordSearchQuery
+		want  []int64
+	}{
+		{"exact content", ZoneRecordSearchQuery{Content: "192.0.2.1"}, []int64{11, 13}},
+		{"exact name content", ZoneRecordSearchQuery{Content: "API.example.net"}, []int64{22}},
+		{"content regexp", ZoneRecordSearchQuery{ContentRegexp: regexp.MustCompile(`^198\.`)}, []int64{12}},
+		{"types", ZoneRecordSearchQuery{Content: "192.0.2.1", Types: []string{"a"}}, []int64{11}},
+		{"name", ZoneRecordSearchQuery{Name: "www"}, []int64{12, 13}},
+		{"apex", ZoneRecordSearchQuery{MatchApex: true}, []int64{11}},
+		{"name regexp", ZoneRecordSearchQuery{NameRegexp: regexp.MustCompile(`^a`)}, []int64{21, 22}},
+		{"ttl range", ZoneRecordSearchQuery{MinTTL: 100, MaxTTL: 300}, []int64{21, 22}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupMockServer()
+			defer teardownMockServer()
+			setupSearchMockServer(t)
+
+			results, err := client.Zones.SearchRecords(context.Background(), "1010", tt.query, &ZoneRecordSearchOptions{Concurrency: 1})
+			assert.NoError(t, err)
+
+			var ids []int64
+			for _, result := range collectSearchResults(t, results) {
+				ids = append(ids, result.Record.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestZonesService_SearchRecords_ServerSideFilters(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "example", r.URL.Query().Get("name_like"))
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "MX", r.URL.Query().Get("type"))
+		assert.Equal(t, "mail", r.URL.Query().Get("name"))
+		fmt.Fprint(w, `{"data":[],"pagination":{"current_page":1,"per_page":30,"total_entries":0,"total_pages":1}}`)
+	})
+
+	results, err := client.Zones.SearchRecords(context.Background(), "1010", ZoneRecordSearchQuery{ZoneNameLike: "example", Types: []string{"mx"}, Name: "mail"}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, collectSearchResults(t, results))
+}
+
+func TestZonesService_SearchRecords_ZoneError(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com"}],"pagination":{"current_page":1,"per_page":30,"total_entries":1,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Zone 'example.com' not found"}`)
+	})
+
+	results, err := client.Zones.SearchRecords(context.Background(), "1010", ZoneRecordSearchQuery{}, nil)
+	assert.NoError(t, err)
+
+	var errs []error
+	for result := range results {
+		errs = append(errs, result.Err)
+		assert.Equal(t, "example.com", result.Zone.Name)
+	}
+	assert.Len(t, errs, 1)
+}
+
+func TestZonesService_SearchRecords_InvalidCIDR(t *testing.T) {
+	c := NewClient(http.DefaultClient)
+
+	_, err := c.Zones.SearchRecords(context.Background(), "1010", ZoneRecordSearchQuery{ContentCIDR: "192.0.2.1"}, nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,424 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+const (
+	reverseZoneIPv4Suffix = "in-addr.arpa"
+	reverseZoneIPv6Suffix = "ip6.arpa"
+)
+
+// ReverseRecordName returns the fully qualified PTR record name for an IP address,
+// such as 4.3.2.1.in-addr.arpa for 1.2.3.4.
+func ReverseRecordName(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	if ip4 := parsed.To4(); ip4 != nil {
+		return reverseIPv4Labels(ip4, 4), nil
+	}
+	return reverseIPv6Labels(parsed, 32), nil
+}
+
+// ReverseZoneNames returns the reverse zone names that cover a CIDR block.
+//
+// Reverse zones are delegated on octet boundaries for IPv4 and on nibble boundaries for IPv6.
+// When the prefix length is not aligned to a boundary, the block is split into
+// the zones of the next longer aligned prefix. For example 192.0.2.0/23 results in
+// 2.0.192.in-addr.arpa and 3.0.192.in-addr.arpa.
+//
+// IPv4 blocks longer than /24 are covered by their enclosing /24 zone,
+// as classless delegation (RFC 2317) is not supported.
+func ReverseZoneNames(cidr string) ([]string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	ones, bits := network.Mask.Size()
+	if ip4 := network.IP.To4(); ip4 != nil {
+		if ones > 24 {
+			ones = 24
+		}
+		labels := (ones + 7) / 8
+		if labels == 0 {
+			return []string{reverseZoneIPv4Suffix}, nil
+		}
+		count := 1 << uint(labels*8-ones)
+		names := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			ip := make(net.IP, 4)
+			copy(ip, ip4)
+			ip[labels-1] += byte(i)
+			names = append(names, reverseIPv4Labels(ip, labels))
+		}
+		return names, nil
+	}
+
+	if bits != 128 {
+		return nil, fmt.Errorf("invalid CIDR %q", cidr)
+	}
+	nibbles := (ones + 3) / 4
+	if nibbles == 0 {
+		return []string{reverseZoneIPv6Suffix}, nil
+	}
+	count := 1 << uint(nibbles*4-ones)
+	names := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		ip := make(net.IP, 16)
+		copy(ip, network.IP)
+		index := (nibbles - 1) / 2
+		if nibbles%2 == 1 {
+			ip[index] += byte(i << 4)
+		} else {
+			ip[index] += byte(i)
+		}
+		names = append(names, reverseIPv6Labels(ip, nibbles))
+	}
+	return names, nil
+}
+
+// ReverseZoneName returns the default reverse zone name for an IP address:
+// the /24 zone for IPv4 and the /64 zone for IPv6.
+func ReverseZoneName(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid IP address %q", ip)
+	}
+
+	if ip4 := parsed.To4(); ip4 != nil {
+		return reverseIPv4Labels(ip4, 3), nil
+	}
+	return reverseIPv6Labels(parsed, 16), nil
+}
+
+// ReverseRecordNameInZone returns the PTR record name for an IP address relative
+// to the given reverse zone, as expected by ZoneRecordAttributes.Name.
+//
+// An error is returned if the address does not belong to the reverse zone.
+func ReverseRecordNameInZone(ip string, reverseZone string) (string, error) {
+	fqdn, err := ReverseRecordName(ip)
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := relativeName(fqdn, reverseZone)
+	if !ok {
+		return "", fmt.Errorf("IP address %v does not belong to reverse zone %v", ip, reverseZone)
+	}
+	return name, nil
+}
+
+func reverseIPv4Labels(ip net.IP, labels int) string {
+	parts := make([]string, 0, labels+1)
+	for i := labels - 1; i >= 0; i-- {
+		parts = append(parts, fmt.Sprintf("%d", ip[i]))
+	}
+	parts = append(parts, reverseZoneIPv4Suffix)
+	return strings.Join(parts, ".")
+}
+
+func reverseIPv6Labels(ip net.IP, nibbles int) string {
+	parts := make([]string, 0, nibbles+1)
+	for i := nibbles - 1; i >= 0; i-- {
+		b := ip[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		parts = append(parts, fmt.Sprintf("%x", b&0x0f))
+	}
+	parts = append(parts, reverseZoneIPv6Suffix)
+	return strings.Join(parts, ".")
+}
+
+// normalizeName returns a lowercase domain name without the trailing dot.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// qualifiedName returns the fully qualified name of a record name relative to zoneName.
+func qualifiedName(name string, zoneName string) string {
+	zoneName = normalizeName(zoneName)
+	if name == "" || name == "@" {
+		return zoneName
+	}
+	return normalizeName(name) + "." + zoneName
+}
+
+// relativeName returns fqdn relative to zoneName, and false if fqdn is outside the zone.
+func relativeName(fqdn string, zoneName string) (string, bool) {
+	fqdn = normalizeName(fqdn)
+	zoneName = normalizeName(zoneName)
+	if fqdn == zoneName {
+		return "", true
+	}
+	if strings.HasSuffix(fqdn, "."+zoneName) {
+		return strings.TrimSuffix(fqdn, "."+zoneName), true
+	}
+	return "", false
+}
+
+// PTRRecord represents a PTR record derived from a forward A or AAAA record.
+type PTRRecord struct {
+	// The IP address of the forward record.
+	IP string `json:"ip"`
+	// The reverse zone the PTR record belongs to.
+	// Empty when the account doesn't host a reverse zone for the address.
+	ReverseZone string `json:"reverse_zone,omitempty"`
+	// The PTR record name, relative to ReverseZone when it is set, fully qualified otherwise.
+	Name string `json:"name"`
+	// The fully qualified name of the forward record, used as PTR content.
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// ZoneRecordAttributes returns the attributes to create the PTR record in its reverse zone.
+func (r PTRRecord) ZoneRecordAttributes() ZoneRecordAttributes {
+	return ZoneRecordAttributes{Type: "PTR", Name: String(r.Name), Content: r.Content, TTL: r.TTL}
+}
+
+// reverseZones lists the reverse zones in the account, sorted from the most to the least specific.
+func (s *ZonesService) reverseZones(ctx context.Context, accountID string) ([]string, error) {
+	zones, err := s.listAllZones(ctx, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, zone := range zones {
+		if zone.Reverse {
+			names = append(names, normalizeName(zone.Name))
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	return names, nil
+}
+
+func matchReverseZone(fqdn string, reverseZones []string) string {
+	for _, zone := range reverseZones {
+		if _, ok := relativeName(fqdn, zone); ok {
+			return zone
+		}
+	}
+	return ""
+}
+
+// GeneratePTRRecords generates the PTR records for the A and AAAA records of a forward zone.
+//
+// Each PTR record is assigned to the most specific reverse zone hosted in the account
+// that covers its address.
+func (s *ZonesService) GeneratePTRRecords(ctx context.Context, accountID string, zoneName string) ([]PTRRecord, error) {
+	reverseZones, err := s.reverseZones(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.listAllRecords(ctx, accountID, zoneName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return generatePTRRecords(zoneName, records, reverseZones), nil
+}
+
+func generatePTRRecords(zoneName string, records []ZoneRecord, reverseZones []string) []PTRRecord {
+	var ptrs []PTRRecord
+	for _, record := range records {
+		if record.Type != "A" && record.Type != "AAAA" {
+			continue
+		}
+
+		fqdn, err := ReverseRecordName(record.Content)
+		if err != nil {
+			continue
+		}
+
+		ptr := PTRRecord{
+			IP:      record.Content,
+			Name:    fqdn,
+			Content: qualifiedName(record.Name, zoneName),
+			TTL:     record.TTL,
+		}
+		if zone := matchReverseZone(fqdn, reverseZones); zone != "" {
+			ptr.ReverseZone = zone
+			ptr.Name, _ = relativeName(fqdn, zone)
+		}
+		ptrs = append(ptrs, ptr)
+	}
+	return ptrs
+}
+
+// ReverseDNSMismatchKind describes a kind of forward/reverse DNS mismatch.
+type ReverseDNSMismatchKind string
+
+const (
+	// ReverseDNSMissingZone is reported when no reverse zone in the account covers the address.
+	ReverseDNSMissingZone ReverseDNSMismatchKind = "missing_reverse_zone"
+	// ReverseDNSMissingPTR is reported when the reverse zone has no PTR record for the address.
+	ReverseDNSMissingPTR ReverseDNSMismatchKind = "missing_ptr"
+	// ReverseDNSPTRMismatch is reported when the PTR records for the address point to other names.
+	ReverseDNSPTRMismatch ReverseDNSMismatchKind = "ptr_mismatch"
+	// ReverseDNSMissingForward is reported when a PTR record points to a name in the forward zone
+	// that has no A or AAAA record for the address.
+	ReverseDNSMissingForward ReverseDNSMismatchKind = "missing_forward"
+)
+
+// ReverseDNSMismatch represents an inconsistency between a forward zone and the reverse zones.
+type ReverseDNSMismatch struct {
+	Kind        ReverseDNSMismatchKind `json:"kind"`
+	IP          string                 `json:"ip"`
+	Name        string                 `json:"name"`
+	ReverseZone string                 `json:"reverse_zone,omitempty"`
+	// The content of the existing PTR records, if any.
+	PTRContent []string `json:"ptr_content,omitempty"`
+}
+
+// CheckReverseDNS compares the A and AAAA records of a forward zone with the PTR records
+// of the reverse zones hosted in the account, and reports the mismatches.
+func (s *ZonesService) CheckReverseDNS(ctx context.Context, accountID string, zoneName string) ([]ReverseDNSMismatch, error) {
+	reverseZones, err := s.reverseZones(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := s.listAllRecords(ctx, accountID, zoneName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrRecords := map[string][]ZoneRecord{}
+	for _, zone := range reverseZones {
+		zoneRecords, err := s.listAllRecords(ctx, accountID, zone, &ZoneRecordListOptions{Type: String("PTR")})
+		if err != nil {
+			return nil, err
+		}
+		ptrRecords[zone] = zoneRecords
+	}
+
+	return checkReverseDNS(zoneName, records, reverseZones, ptrRecords), nil
+}
+
+func checkReverseDNS(zoneName string, records []ZoneRecord, reverseZones []string, ptrRecords map[string][]ZoneRecord) []ReverseDNSMismatch {
+	// PTR content indexed by fully qualified reverse name
+	ptrContent := map[string][]string{}
+	for zone, zoneRecords := range ptrRecords {
+		for _, record := range zoneRecords {
+			if record.Type != "PTR" {
+				continue
+			}
+			fqdn := qualifiedName(record.Name, zone)
+			ptrContent[fqdn] = append(ptrContent[fqdn], normalizeName(record.Content))
+		}
+	}
+
+	var mismatches []ReverseDNSMismatch
+	forward := map[string]bool{}
+	for _, ptr := range generatePTRRecords(zoneName, records, reverseZones) {
+		fqdn, _ := ReverseRecordName(ptr.IP)
+		forward[fqdn+" "+ptr.Content] = true
+
+		mismatch := ReverseDNSMismatch{IP: ptr.IP, Name: ptr.Content, ReverseZone: ptr.ReverseZone}
+		contents := ptrContent[fqdn]
+		switch {
+		case ptr.ReverseZone == "":
+			mismatch.Kind = ReverseDNSMissingZone
+		case len(contents) == 0:
+			mismatch.Kind = ReverseDNSMissingPTR
+		case !containsString(contents, ptr.Content):
+			mismatch.Kind = ReverseDNSPTRMismatch
+			mismatch.PTRContent = contents
+		default:
+			continue
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	for zone, zoneRecords := range ptrRecords {
+		for _, record := range zoneRecords {
+			content := normalizeName(record.Content)
+			if record.Type != "PTR" {
+				continue
+			}
+			if _, ok := relativeName(content, zoneName); !ok {
+				continue
+			}
+			fqdn := qualifiedName(record.Name, zone)
+			if forward[fqdn+" "+content] {
+				continue
+			}
+			mismatches = append(mismatches, ReverseDNSMismatch{
+				Kind:        ReverseDNSMissingForward,
+				IP:          reverseNameToIP(fqdn),
+				Name:        content,
+				ReverseZone: zone,
+				PTRContent:  []string{content},
+			})
+		}
+	}
+
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		if mismatches[i].Name != mismatches[j].Name {
+			return mismatches[i].Name < mismatches[j].Name
+		}
+		return mismatches[i].IP < mismatches[j].IP
+	})
+	return mismatches
+}
+
+// reverseNameToIP converts a fully qualified PTR name back to an IP address.
+// It returns an empty string if the name doesn't represent a complete address.
+func reverseNameToIP(fqdn string) string {
+	fqdn = normalizeName(fqdn)
+
+	if labels, ok := relativeName(fqdn, reverseZoneIPv4Suffix); ok {
+		parts := strings.Split(labels, ".")
+		if len(parts) != 4 {
+			return ""
+		}
+		for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+			parts[i], parts[j] = parts[j], parts[i]
+		}
+		ip := net.ParseIP(strings.Join(parts, "."))
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	}
+
+	if labels, ok := relativeName(fqdn, reverseZoneIPv6Suffix); ok {
+		parts := strings.Split(labels, ".")
+		if len(parts) != 32 {
+			return ""
+		}
+		var b strings.Builder
+		for i := len(parts) - 1; i >= 0; i-- {
+			b.WriteString(parts[i])
+			if i%4 == 0 && i != 0 {
+				b.WriteString(":")
+			}
+		}
+		ip := net.ParseIP(b.String())
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	}
+
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
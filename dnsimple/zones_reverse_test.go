@@ -0,0 +1,151 @@
+package dnsimple
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseRecordName(t *testing.T) {
+	name, err := ReverseRecordName("192.0.2.10")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.2.0.192.in-addr.arpa", name)
+
+	name, err = ReverseRecordName("2001:db8::567:89ab")
+	assert.NoError(t, err)
+	assert.Equal(t, "b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", name)
+
+	_, err = ReverseRecordName("example.com")
+	assert.Error(t, err)
+}
+
+func TestReverseZoneName(t *testing.T) {
+	name, err := ReverseZoneName("192.0.2.10")
+	assert.NoError(t, err)
+	assert.Equal(t, "2.0.192.in-addr.arpa", name)
+
+	name, err = ReverseZoneName("2001:db8::1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", name)
+}
+
+func TestReverseZoneNames(t *testing.T) {
+	tests := []struct {
+		cidr string
+		want []string
+	}{
+		{"192.0.2.0/24", []string{"2.0.192.in-addr.arpa"}},
+		{"10.0.0.0/8", []string{"10.in-addr.arpa"}},
+		{"192.0.2.0/23", []string{"2.0.192.in-addr.arpa", "3.0.192.in-addr.arpa"}},
+		{"192.0.2.128/25", []string{"2.0.192.in-addr.arpa"}},
+		{"2001:db8::/32", []string{"8.b.d.0.1.0.0.2.ip6.arpa"}},
+		{"2001:db8::/31", []string{"8.b.d.0.1.0.0.2.ip6.arpa", "9.b.d.0.1.0.0.2.ip6.arpa"}},
+		{"2001:db8::/35", []string{"0.8.b.d.0.1.0.0.2.ip6.arpa", "1.8.b.d.0.1.0.0.2.ip6.arpa"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cidr, func(t *testing.T) {
+			got, err := ReverseZoneNames(tt.cidr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ReverseZoneNames("192.0.2.0")
+	assert.Error(t, err)
+}
+
+func TestReverseRecordNameInZone(t *testing.T) {
+	name, err := ReverseRecordNameInZone("192.0.2.10", "2.0.192.in-addr.arpa")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", name)
+
+	_, err = ReverseRecordNameInZone("198.51.100.10", "2.0.192.in-addr.arpa")
+	assert.Error(t, err)
+}
+
+func TestReverseNameToIP(t *testing.T) {
+	assert.Equal(t, "192.0.2.10", reverseNameToIP("10.2.0.192.in-addr.arpa."))
+	assert.Equal(t, "2001:db8::567:89ab", reverseNameToIP("b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"))
+	assert.Equal(t, "", reverseNameToIP("2.0.192.in-addr.arpa"))
+}
+
+func setupReverseDNSMockServer(t *testing.T) {
+	mux.HandleFunc("/v2/1010/zones", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"example.com","reverse":false},{"id":2,"name":"2.0.192.in-addr.arpa","reverse":true}],"pagination":{"current_page":1,"per_page":30,"total_entries":2,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"","type":"A","content":"192.0.2.1","ttl":3600},
+			{"id":2,"name":"www","type":"A","content":"192.0.2.2","ttl":3600},
+			{"id":3,"name":"mail","type":"A","content":"192.0.2.3","ttl":3600},
+			{"id":4,"name":"v6","type":"AAAA","content":"2001:db8::1","ttl":600},
+			{"id":5,"name":"","type":"MX","content":"mail.example.com","ttl":3600}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":5,"total_pages":1}}`)
+	})
+	mux.HandleFunc("/v2/1010/zones/2.0.192.in-addr.arpa/records", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "PTR", r.URL.Query().Get("type"))
+		fmt.Fprint(w, `{"data":[
+			{"id":10,"name":"1","type":"PTR","content":"example.com."},
+			{"id":11,"name":"2","type":"PTR","content":"other.example.net"},
+			{"id":12,"name":"9","type":"PTR","content":"old.example.com"}
+		],"pagination":{"current_page":1,"per_page":30,"total_entries":3,"total_pages":1}}`)
+	})
+}
+
+func TestZonesService_GeneratePTRRecords(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupReverseDNSMockServer(t)
+
+	ptrs, err := client.Zones.GeneratePTRRecords(context.Background(), "1010", "example.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, ptrs, 4)
+	assert.Equal(t, PTRRecord{IP: "192.0.2.2", ReverseZone: "2.0.192.in-addr.arpa", Name: "2", Content: "www.example.com", TTL: 3600}, ptrs[1])
+	assert.Equal(t, "", ptrs[3].ReverseZone)
+	assert.Equal(t, "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", ptrs[3].Name)
+	assert.Equal(t, ZoneRecordAttributes{Type: "PTR", Name: String("2"), Content: "www.example.com", TTL: 3600}, ptrs[1].ZoneRecordAttributes())
+}
+
+func TestZonesService_CheckReverseDNS(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+	setupReverseDNSMockServer(t)
+
+	mismatches, err := client.Zones.CheckReverseDNS(context.Background(), "1010", "example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []ReverseDNSMismatch{
+		{Kind: ReverseDNSMissingPTR, IP: "192.0.2.3", Name: "mail.example.com", ReverseZone: "2.0.192.in-addr.arpa"},
+		{Kind: ReverseDNSMissingForward, IP: "192.0.2.9", Name: "old.example.com", ReverseZone: "2.0.192.in-addr.arpa", PTRContent: []string{"old.example.com"}},
+		{Kind: ReverseDNSMissingZone, IP: "2001:db8::1", Name: "v6.example.com"},
+		{Kind: ReverseDNSPTRMismatch, IP: "192.0.2.2", Name: "www.example.com", ReverseZone: "2.0.192.in-addr.arpa", PTRContent: []string{"other.example.net"}},
+	}, mismatches)
+}
+
+func TestZonesService_listAllZones(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones", func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		httpResponse := httpResponseFixture(t, fmt.Sprintf("/api/pages-%vof3.http", page))
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	zones, err := client.Zones.listAllZones(context.Background(), "1010", nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, zones, 5)
+	assert.Equal(t, int64(5), zones[4].ID)
+}
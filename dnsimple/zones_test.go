@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,3 +115,114 @@ func TestZonesService_GetZoneFile(t *testing.T) {
 	}
 	assert.Equal(t, wantSingle, zoneFile)
 }
+
+func TestZonesService_ActivateDns(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/activation", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/activateZoneService/success.http")
+
+		testMethod(t, r, "PUT")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	zoneResponse, err := client.Zones.ActivateDns(context.Background(), "1010", "example.com")
+
+	assert.NoError(t, err)
+	zone := zoneResponse.Data
+	wantSingle := &Zone{
+		ID:        1,
+		AccountID: 1010,
+		Name:      "example.com",
+		Reverse:   false,
+		Active:    true,
+		CreatedAt: "2022-09-28T04:45:24Z",
+		UpdatedAt: "2023-07-06T11:19:48Z"}
+	assert.Equal(t, wantSingle, zone)
+}
+
+func TestZonesService_DeactivateDns(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/activation", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/deactivateZoneService/success.http")
+
+		testMethod(t, r, "DELETE")
+		testHeaders(t, r)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	zoneResponse, err := client.Zones.DeactivateDns(context.Background(), "1010", "example.com")
+
+	assert.NoError(t, err)
+	zone := zoneResponse.Data
+	assert.Equal(t, "example.com", zone.Name)
+	assert.False(t, zone.Active)
+	assert.Equal(t, "2023-08-08T04:19:52Z", zone.UpdatedAt)
+}
+
+func TestZonesService_UpdateNsRecords(t *testing.T) {
+	setupMockServer()
+	defer teardownMockServer()
+
+	mux.HandleFunc("/v2/1010/zones/example.com/ns_records", func(w http.ResponseWriter, r *http.Request) {
+		httpResponse := httpResponseFixture(t, "/api/updateZoneNsRecords/success.http")
+
+		testMethod(t, r, "PUT")
+		testHeaders(t, r)
+
+		want := map[string]interface{}{
+			"ns_names":   []interface{}{"ns1.example.com", "ns2.example.com"},
+			"ns_set_ids": []interface{}{float64(1), float64(2)},
+		}
+		testRequestJSON(t, r, want)
+
+		w.WriteHeader(httpResponse.StatusCode)
+		_, _ = io.Copy(w, httpResponse.Body)
+	})
+
+	input := &ZoneNsRecordsInput{NsNames: []string{"ns1.example.com", "ns2.example.com"}, NsSetIDs: []int64{1, 2}}
+
+	nsRecordsResponse, err := client.Zones.UpdateNsRecords(context.Background(), "1010", "example.com", input)
+
+	assert.NoError(t, err)
+	records := nsRecordsResponse.Data
+	assert.Len(t, records, 4)
+	assert.Equal(t, int64(64784), records[0].ID)
+	assert.Equal(t, "NS", records[0].Type)
+	assert.Equal(t, "ns1.example.com", records[0].Content)
+	assert.True(t, records[0].SystemRecord)
+}
+
+func TestZonesService_UpdateNsRecords_Invalid(t *testing.T) {
+	c := NewClient(http.DefaultClient)
+
+	_, err := c.Zones.UpdateNsRecords(context.Background(), "1010", "example.com", &ZoneNsRecordsInput{})
+	assert.Error(t, err)
+
+	_, err = c.Zones.UpdateNsRecords(context.Background(), "1010", "example.com", nil)
+	assert.Error(t, err)
+
+	_, err = c.Zones.UpdateNsRecords(context.Background(), "1010", "example.com", &ZoneNsRecordsInput{NsNames: []string{"ns1.example.com", "-ns2.example.com"}})
+	assert.EqualError(t, err, `invalid host name "-ns2.example.com"`)
+}
+
+func TestValidateHostname(t *testing.T) {
+	assert.NoError(t, validateHostname("ns1.dnsimple.com"))
+	assert.NoError(t, validateHostname("ns1.dnsimple.com."))
+	assert.NoError(t, validateHostname("a-1.example.co.uk"))
+
+	assert.Error(t, validateHostname(""))
+	assert.Error(t, validateHostname("localhost"))
+	assert.Error(t, validateHostname("ns1..example.com"))
+	assert.Error(t, validateHostname("ns_1.example.com"))
+	assert.Error(t, validateHostname("ns1-.example.com"))
+	assert.Error(t, validateHostname(strings.Repeat("a", 64)+".example.com"))
+}